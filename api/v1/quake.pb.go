@@ -122,6 +122,41 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_d542a431c78f4780, []int{2}
 }
 
+// EventType is a type of a change on earthquake data.
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	// An earthquake not seen before.
+	EventType_EVENT_TYPE_ADDED EventType = 1
+	// An earthquake with a new "updated_time".
+	EventType_EVENT_TYPE_UPDATED EventType = 2
+	// An earthquake with status changed to STATUS_DELETED.
+	EventType_EVENT_TYPE_DELETED EventType = 3
+)
+
+var EventType_name = map[int32]string{
+	0: "EVENT_TYPE_UNSPECIFIED",
+	1: "EVENT_TYPE_ADDED",
+	2: "EVENT_TYPE_UPDATED",
+	3: "EVENT_TYPE_DELETED",
+}
+
+var EventType_value = map[string]int32{
+	"EVENT_TYPE_UNSPECIFIED": 0,
+	"EVENT_TYPE_ADDED":       1,
+	"EVENT_TYPE_UPDATED":     2,
+	"EVENT_TYPE_DELETED":     3,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{3}
+}
+
 // EarthquakeCollection represents a feature collection of earthquakes based on
 // the "GeoJSON Summary Format" of the USGS Earthquake Hazards program.
 type EarthquakeCollection struct {
@@ -213,10 +248,17 @@ type Earthquake struct {
 	// GeoJSON property: "sig".
 	Significance int32 `protobuf:"varint,9,opt,name=significance,proto3" json:"significance,omitempty"`
 	// Detailed information when available. Note that this field can be null.
-	Details              *EarthquakeDetails `protobuf:"bytes,10,opt,name=details,proto3" json:"details,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Details *EarthquakeDetails `protobuf:"bytes,10,opt,name=details,proto3" json:"details,omitempty"`
+	// Distance (meters) from a focus position (or a center of focus bounds)
+	// to the epicenter. Set only on earthquakes listed with a focus.
+	DistanceMeters float64 `protobuf:"fixed64,11,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	// Initial bearing (degrees clockwise from north, [0.0, 360.0[) from a
+	// focus position (or a center of focus bounds) to the epicenter. Set
+	// only on earthquakes listed with a focus.
+	BearingDegrees       float64  `protobuf:"fixed64,12,opt,name=bearing_degrees,json=bearingDegrees,proto3" json:"bearing_degrees,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Earthquake) Reset()         { *m = Earthquake{} }
@@ -314,6 +356,20 @@ func (m *Earthquake) GetDetails() *EarthquakeDetails {
 	return nil
 }
 
+func (m *Earthquake) GetDistanceMeters() float64 {
+	if m != nil {
+		return m.DistanceMeters
+	}
+	return 0
+}
+
+func (m *Earthquake) GetBearingDegrees() float64 {
+	if m != nil {
+		return m.BearingDegrees
+	}
+	return 0
+}
+
 // Earthquake detailed properties.
 type EarthquakeDetails struct {
 	// USGS docs: "A (generally) two-character network identifier with a (generally)
@@ -377,7 +433,13 @@ type EarthquakeDetails struct {
 	// https://www.usgs.gov/natural-hazards/earthquake-hazards/science/magnitude-types?qt-science_center_objects=0#qt-science_center_objects
 	MagType string `protobuf:"bytes,18,opt,name=mag_type,json=magType,proto3" json:"mag_type,omitempty"`
 	// USGS docs: "Type of seismic event".
-	Type                 Type     `protobuf:"varint,19,opt,name=type,proto3,enum=quake.api.v1.Type" json:"type,omitempty"`
+	Type Type `protobuf:"varint,19,opt,name=type,proto3,enum=quake.api.v1.Type" json:"type,omitempty"`
+	// Uncertainties of the preferred origin (horizontal and depth in km, time in
+	// seconds) and the preferred magnitude. Available on QuakeML data only.
+	HorizontalError      float32  `protobuf:"fixed32,20,opt,name=horizontal_error,json=horizontalError,proto3" json:"horizontal_error,omitempty"`
+	DepthError           float32  `protobuf:"fixed32,21,opt,name=depth_error,json=depthError,proto3" json:"depth_error,omitempty"`
+	TimeError            float32  `protobuf:"fixed32,22,opt,name=time_error,json=timeError,proto3" json:"time_error,omitempty"`
+	MagnitudeError       float32  `protobuf:"fixed32,23,opt,name=magnitude_error,json=magnitudeError,proto3" json:"magnitude_error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -541,194 +603,1150 @@ func (m *EarthquakeDetails) GetType() Type {
 	return Type_TYPE_UNSPECIFIED
 }
 
-// EarthquakeMetadata contains meta data for a set of earthquakes.
-type EarthquakeMetadata struct {
-	// USGS docs: "Time (seconds) when the feed was most recently updated".
-	// Time is UTC time since Unix epoch 1970-01-01T00:00:00Z.
-	GeneratedTime int64 `protobuf:"varint,1,opt,name=generated_time,json=generatedTime,proto3" json:"generated_time,omitempty"`
-	// USGS docs: "Url of the feed".
-	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	// USGS docs: "The title of the feed". Typical values:
-	// “USGS Magnitude 1+ Earthquakes, Past Day”,
-	// “USGS Magnitude 4.5+ Earthquakes, Past Month”
-	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	// USGS docs: "Version of API that generated feed".
-	Api string `protobuf:"bytes,4,opt,name=api,proto3" json:"api,omitempty"`
-	// USGS docs: "Number of earthquakes in feed".
-	Count int32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
-	// USGS docs: "HTTP status code of response".
-	HttpStatus           string   `protobuf:"bytes,6,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *EarthquakeDetails) GetHorizontalError() float32 {
+	if m != nil {
+		return m.HorizontalError
+	}
+	return 0
 }
 
-func (m *EarthquakeMetadata) Reset()         { *m = EarthquakeMetadata{} }
-func (m *EarthquakeMetadata) String() string { return proto.CompactTextString(m) }
-func (*EarthquakeMetadata) ProtoMessage()    {}
-func (*EarthquakeMetadata) Descriptor() ([]byte, []int) {
+func (m *EarthquakeDetails) GetDepthError() float32 {
+	if m != nil {
+		return m.DepthError
+	}
+	return 0
+}
+
+func (m *EarthquakeDetails) GetTimeError() float32 {
+	if m != nil {
+		return m.TimeError
+	}
+	return 0
+}
+
+func (m *EarthquakeDetails) GetMagnitudeError() float32 {
+	if m != nil {
+		return m.MagnitudeError
+	}
+	return 0
+}
+
+// EarthquakeProducts contains summaries of products (preferred ones) available
+// on the "GeoJSON Detail Format" of the USGS Earthquake Hazards program.
+// Note that any of these fields can be null if no such product is available.
+type EarthquakeProducts struct {
+	// USGS docs: "Origins describe the location and time of an earthquake".
+	Origin *OriginProduct `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	// USGS docs: "Moment tensors describe the mechanism of an earthquake".
+	MomentTensor *MomentTensorProduct `protobuf:"bytes,2,opt,name=moment_tensor,json=momentTensor,proto3" json:"moment_tensor,omitempty"`
+	// USGS docs: "Focal mechanisms describe the fault plane of an earthquake".
+	FocalMechanism *FocalMechanismProduct `protobuf:"bytes,3,opt,name=focal_mechanism,json=focalMechanism,proto3" json:"focal_mechanism,omitempty"`
+	// ShakeMap summary of ground shaking.
+	Shakemap *ShakeMapProduct `protobuf:"bytes,4,opt,name=shakemap,proto3" json:"shakemap,omitempty"`
+	// PAGER summary (the "losspager" product) of estimated impacts.
+	Pager *PagerProduct `protobuf:"bytes,5,opt,name=pager,proto3" json:"pager,omitempty"`
+	// Did You Feel It? summary of felt reports.
+	Dyfi                 *DyfiProduct `protobuf:"bytes,6,opt,name=dyfi,proto3" json:"dyfi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *EarthquakeProducts) Reset()         { *m = EarthquakeProducts{} }
+func (m *EarthquakeProducts) String() string { return proto.CompactTextString(m) }
+func (*EarthquakeProducts) ProtoMessage()    {}
+func (*EarthquakeProducts) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d542a431c78f4780, []int{3}
 }
 
-func (m *EarthquakeMetadata) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EarthquakeMetadata.Unmarshal(m, b)
+func (m *EarthquakeProducts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EarthquakeProducts.Unmarshal(m, b)
 }
-func (m *EarthquakeMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EarthquakeMetadata.Marshal(b, m, deterministic)
+func (m *EarthquakeProducts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EarthquakeProducts.Marshal(b, m, deterministic)
 }
-func (m *EarthquakeMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EarthquakeMetadata.Merge(m, src)
+func (m *EarthquakeProducts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EarthquakeProducts.Merge(m, src)
 }
-func (m *EarthquakeMetadata) XXX_Size() int {
-	return xxx_messageInfo_EarthquakeMetadata.Size(m)
+func (m *EarthquakeProducts) XXX_Size() int {
+	return xxx_messageInfo_EarthquakeProducts.Size(m)
 }
-func (m *EarthquakeMetadata) XXX_DiscardUnknown() {
-	xxx_messageInfo_EarthquakeMetadata.DiscardUnknown(m)
+func (m *EarthquakeProducts) XXX_DiscardUnknown() {
+	xxx_messageInfo_EarthquakeProducts.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EarthquakeMetadata proto.InternalMessageInfo
+var xxx_messageInfo_EarthquakeProducts proto.InternalMessageInfo
 
-func (m *EarthquakeMetadata) GetGeneratedTime() int64 {
+func (m *EarthquakeProducts) GetOrigin() *OriginProduct {
 	if m != nil {
-		return m.GeneratedTime
+		return m.Origin
 	}
-	return 0
+	return nil
 }
 
-func (m *EarthquakeMetadata) GetUrl() string {
+func (m *EarthquakeProducts) GetMomentTensor() *MomentTensorProduct {
 	if m != nil {
-		return m.Url
+		return m.MomentTensor
 	}
-	return ""
+	return nil
 }
 
-func (m *EarthquakeMetadata) GetTitle() string {
+func (m *EarthquakeProducts) GetFocalMechanism() *FocalMechanismProduct {
 	if m != nil {
-		return m.Title
+		return m.FocalMechanism
+	}
+	return nil
+}
+
+func (m *EarthquakeProducts) GetShakemap() *ShakeMapProduct {
+	if m != nil {
+		return m.Shakemap
+	}
+	return nil
+}
+
+func (m *EarthquakeProducts) GetPager() *PagerProduct {
+	if m != nil {
+		return m.Pager
+	}
+	return nil
+}
+
+func (m *EarthquakeProducts) GetDyfi() *DyfiProduct {
+	if m != nil {
+		return m.Dyfi
+	}
+	return nil
+}
+
+// ProductInfo contains information common to all products.
+type ProductInfo struct {
+	// USGS docs: "The network that contributed the product".
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// USGS docs: "The network assigned code for the product".
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// Time (seconds) when the product was most recently updated.
+	// Time is UTC time since Unix epoch 1970-01-01T00:00:00Z.
+	UpdatedTime int64 `protobuf:"varint,3,opt,name=updated_time,json=updatedTime,proto3" json:"updated_time,omitempty"`
+	// Status of the product. Typical values: "UPDATE", "DELETE".
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProductInfo) Reset()         { *m = ProductInfo{} }
+func (m *ProductInfo) String() string { return proto.CompactTextString(m) }
+func (*ProductInfo) ProtoMessage()    {}
+func (*ProductInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{4}
+}
+
+func (m *ProductInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProductInfo.Unmarshal(m, b)
+}
+func (m *ProductInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProductInfo.Marshal(b, m, deterministic)
+}
+func (m *ProductInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProductInfo.Merge(m, src)
+}
+func (m *ProductInfo) XXX_Size() int {
+	return xxx_messageInfo_ProductInfo.Size(m)
+}
+func (m *ProductInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProductInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProductInfo proto.InternalMessageInfo
+
+func (m *ProductInfo) GetSource() string {
+	if m != nil {
+		return m.Source
 	}
 	return ""
 }
 
-func (m *EarthquakeMetadata) GetApi() string {
+func (m *ProductInfo) GetCode() string {
 	if m != nil {
-		return m.Api
+		return m.Code
 	}
 	return ""
 }
 
-func (m *EarthquakeMetadata) GetCount() int32 {
+func (m *ProductInfo) GetUpdatedTime() int64 {
 	if m != nil {
-		return m.Count
+		return m.UpdatedTime
 	}
 	return 0
 }
 
-func (m *EarthquakeMetadata) GetHttpStatus() string {
+func (m *ProductInfo) GetStatus() string {
 	if m != nil {
-		return m.HttpStatus
+		return m.Status
 	}
 	return ""
 }
 
-// GeoBoundsE7 is a geographic bounding box (WGS84 latitude and longitude
-// are in E7 format, height is centimeters with negative values meaning depth).
-// The E7 format with 32 bit ints is used to optimize wire transfer.
-type GeoBoundsE7 struct {
-	MinLatitude          int32    `protobuf:"fixed32,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
-	MinLongitude         int32    `protobuf:"fixed32,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
-	MinHeight            int32    `protobuf:"zigzag32,3,opt,name=min_height,json=minHeight,proto3" json:"min_height,omitempty"`
-	MaxLatitude          int32    `protobuf:"fixed32,4,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
-	MaxLongitude         int32    `protobuf:"fixed32,5,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
-	MaxHeight            int32    `protobuf:"zigzag32,6,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+// OriginProduct is a summary of the "origin" product.
+type OriginProduct struct {
+	Info *ProductInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// The hypocenter (height is centimeters with negative values meaning depth).
+	Position *GeoPointE7 `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	// Time (seconds) when the event occurred.
+	// Time is UTC time since Unix epoch 1970-01-01T00:00:00Z.
+	Time      int64   `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	Magnitude float32 `protobuf:"fixed32,4,opt,name=magnitude,proto3" json:"magnitude,omitempty"`
+	MagType   string  `protobuf:"bytes,5,opt,name=mag_type,json=magType,proto3" json:"mag_type,omitempty"`
+	// Uncertainties for the location (km) and the magnitude.
+	HorizontalError float32 `protobuf:"fixed32,6,opt,name=horizontal_error,json=horizontalError,proto3" json:"horizontal_error,omitempty"`
+	VerticalError   float32 `protobuf:"fixed32,7,opt,name=vertical_error,json=verticalError,proto3" json:"vertical_error,omitempty"`
+	MagnitudeError  float32 `protobuf:"fixed32,8,opt,name=magnitude_error,json=magnitudeError,proto3" json:"magnitude_error,omitempty"`
+	// USGS docs: "The total number of seismic stations used to determine
+	// earthquake location".
+	NumStationsUsed int32 `protobuf:"varint,9,opt,name=num_stations_used,json=numStationsUsed,proto3" json:"num_stations_used,omitempty"`
+	// Azimuthal gap (degrees) and standard error (seconds).
+	AzimuthalGap  float32 `protobuf:"fixed32,10,opt,name=azimuthal_gap,json=azimuthalGap,proto3" json:"azimuthal_gap,omitempty"`
+	StandardError float32 `protobuf:"fixed32,11,opt,name=standard_error,json=standardError,proto3" json:"standard_error,omitempty"`
+	// Review status. Typical values: "reviewed", "automatic".
+	ReviewStatus         string   `protobuf:"bytes,12,opt,name=review_status,json=reviewStatus,proto3" json:"review_status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GeoBoundsE7) Reset()         { *m = GeoBoundsE7{} }
-func (m *GeoBoundsE7) String() string { return proto.CompactTextString(m) }
-func (*GeoBoundsE7) ProtoMessage()    {}
-func (*GeoBoundsE7) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d542a431c78f4780, []int{4}
+func (m *OriginProduct) Reset()         { *m = OriginProduct{} }
+func (m *OriginProduct) String() string { return proto.CompactTextString(m) }
+func (*OriginProduct) ProtoMessage()    {}
+func (*OriginProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{5}
 }
 
-func (m *GeoBoundsE7) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GeoBoundsE7.Unmarshal(m, b)
+func (m *OriginProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OriginProduct.Unmarshal(m, b)
 }
-func (m *GeoBoundsE7) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GeoBoundsE7.Marshal(b, m, deterministic)
+func (m *OriginProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OriginProduct.Marshal(b, m, deterministic)
 }
-func (m *GeoBoundsE7) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GeoBoundsE7.Merge(m, src)
+func (m *OriginProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OriginProduct.Merge(m, src)
 }
-func (m *GeoBoundsE7) XXX_Size() int {
-	return xxx_messageInfo_GeoBoundsE7.Size(m)
+func (m *OriginProduct) XXX_Size() int {
+	return xxx_messageInfo_OriginProduct.Size(m)
 }
-func (m *GeoBoundsE7) XXX_DiscardUnknown() {
-	xxx_messageInfo_GeoBoundsE7.DiscardUnknown(m)
+func (m *OriginProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_OriginProduct.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GeoBoundsE7 proto.InternalMessageInfo
+var xxx_messageInfo_OriginProduct proto.InternalMessageInfo
 
-func (m *GeoBoundsE7) GetMinLatitude() int32 {
+func (m *OriginProduct) GetInfo() *ProductInfo {
 	if m != nil {
-		return m.MinLatitude
+		return m.Info
+	}
+	return nil
+}
+
+func (m *OriginProduct) GetPosition() *GeoPointE7 {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *OriginProduct) GetTime() int64 {
+	if m != nil {
+		return m.Time
 	}
 	return 0
 }
 
-func (m *GeoBoundsE7) GetMinLongitude() int32 {
+func (m *OriginProduct) GetMagnitude() float32 {
 	if m != nil {
-		return m.MinLongitude
+		return m.Magnitude
 	}
 	return 0
 }
 
-func (m *GeoBoundsE7) GetMinHeight() int32 {
+func (m *OriginProduct) GetMagType() string {
 	if m != nil {
-		return m.MinHeight
+		return m.MagType
+	}
+	return ""
+}
+
+func (m *OriginProduct) GetHorizontalError() float32 {
+	if m != nil {
+		return m.HorizontalError
 	}
 	return 0
 }
 
-func (m *GeoBoundsE7) GetMaxLatitude() int32 {
+func (m *OriginProduct) GetVerticalError() float32 {
 	if m != nil {
-		return m.MaxLatitude
+		return m.VerticalError
 	}
 	return 0
 }
 
-func (m *GeoBoundsE7) GetMaxLongitude() int32 {
+func (m *OriginProduct) GetMagnitudeError() float32 {
 	if m != nil {
-		return m.MaxLongitude
+		return m.MagnitudeError
 	}
 	return 0
 }
 
-func (m *GeoBoundsE7) GetMaxHeight() int32 {
+func (m *OriginProduct) GetNumStationsUsed() int32 {
 	if m != nil {
-		return m.MaxHeight
+		return m.NumStationsUsed
 	}
 	return 0
 }
 
-// GeoPointE7 is a geographic point (WGS84 latitude and longitude are
-// in E7 format and height is centimeters with negative values meaning depth).
-// The E7 format with 32 bit ints is used to optimize wire transfer.
-type GeoPointE7 struct {
-	Latitude             int32    `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude            int32    `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Height               int32    `protobuf:"zigzag32,3,opt,name=height,proto3" json:"height,omitempty"`
+func (m *OriginProduct) GetAzimuthalGap() float32 {
+	if m != nil {
+		return m.AzimuthalGap
+	}
+	return 0
+}
+
+func (m *OriginProduct) GetStandardError() float32 {
+	if m != nil {
+		return m.StandardError
+	}
+	return 0
+}
+
+func (m *OriginProduct) GetReviewStatus() string {
+	if m != nil {
+		return m.ReviewStatus
+	}
+	return ""
+}
+
+// NodalPlane defines a fault plane (angles in degrees).
+type NodalPlane struct {
+	Strike               float32  `protobuf:"fixed32,1,opt,name=strike,proto3" json:"strike,omitempty"`
+	Dip                  float32  `protobuf:"fixed32,2,opt,name=dip,proto3" json:"dip,omitempty"`
+	Rake                 float32  `protobuf:"fixed32,3,opt,name=rake,proto3" json:"rake,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GeoPointE7) Reset()         { *m = GeoPointE7{} }
-func (m *GeoPointE7) String() string { return proto.CompactTextString(m) }
-func (*GeoPointE7) ProtoMessage()    {}
-func (*GeoPointE7) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d542a431c78f4780, []int{5}
+func (m *NodalPlane) Reset()         { *m = NodalPlane{} }
+func (m *NodalPlane) String() string { return proto.CompactTextString(m) }
+func (*NodalPlane) ProtoMessage()    {}
+func (*NodalPlane) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{6}
+}
+
+func (m *NodalPlane) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodalPlane.Unmarshal(m, b)
+}
+func (m *NodalPlane) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodalPlane.Marshal(b, m, deterministic)
+}
+func (m *NodalPlane) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodalPlane.Merge(m, src)
+}
+func (m *NodalPlane) XXX_Size() int {
+	return xxx_messageInfo_NodalPlane.Size(m)
+}
+func (m *NodalPlane) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodalPlane.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodalPlane proto.InternalMessageInfo
+
+func (m *NodalPlane) GetStrike() float32 {
+	if m != nil {
+		return m.Strike
+	}
+	return 0
+}
+
+func (m *NodalPlane) GetDip() float32 {
+	if m != nil {
+		return m.Dip
+	}
+	return 0
+}
+
+func (m *NodalPlane) GetRake() float32 {
+	if m != nil {
+		return m.Rake
+	}
+	return 0
+}
+
+// MomentTensorProduct is a summary of the "moment-tensor" product.
+type MomentTensorProduct struct {
+	Info                 *ProductInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	DerivedMagnitude     float32      `protobuf:"fixed32,2,opt,name=derived_magnitude,json=derivedMagnitude,proto3" json:"derived_magnitude,omitempty"`
+	DerivedMagnitudeType string       `protobuf:"bytes,3,opt,name=derived_magnitude_type,json=derivedMagnitudeType,proto3" json:"derived_magnitude_type,omitempty"`
+	// Derived depth as height (centimeters with negative values meaning depth).
+	DerivedHeight int32 `protobuf:"zigzag32,4,opt,name=derived_height,json=derivedHeight,proto3" json:"derived_height,omitempty"`
+	// Scalar moment (N-m).
+	ScalarMoment float64 `protobuf:"fixed64,5,opt,name=scalar_moment,json=scalarMoment,proto3" json:"scalar_moment,omitempty"`
+	// Tensor components (N-m).
+	TensorMrr            float64     `protobuf:"fixed64,6,opt,name=tensor_mrr,json=tensorMrr,proto3" json:"tensor_mrr,omitempty"`
+	TensorMtt            float64     `protobuf:"fixed64,7,opt,name=tensor_mtt,json=tensorMtt,proto3" json:"tensor_mtt,omitempty"`
+	TensorMpp            float64     `protobuf:"fixed64,8,opt,name=tensor_mpp,json=tensorMpp,proto3" json:"tensor_mpp,omitempty"`
+	TensorMrt            float64     `protobuf:"fixed64,9,opt,name=tensor_mrt,json=tensorMrt,proto3" json:"tensor_mrt,omitempty"`
+	TensorMrp            float64     `protobuf:"fixed64,10,opt,name=tensor_mrp,json=tensorMrp,proto3" json:"tensor_mrp,omitempty"`
+	TensorMtp            float64     `protobuf:"fixed64,11,opt,name=tensor_mtp,json=tensorMtp,proto3" json:"tensor_mtp,omitempty"`
+	PercentDoubleCouple  float32     `protobuf:"fixed32,12,opt,name=percent_double_couple,json=percentDoubleCouple,proto3" json:"percent_double_couple,omitempty"`
+	NodalPlane1          *NodalPlane `protobuf:"bytes,13,opt,name=nodal_plane1,json=nodalPlane1,proto3" json:"nodal_plane1,omitempty"`
+	NodalPlane2          *NodalPlane `protobuf:"bytes,14,opt,name=nodal_plane2,json=nodalPlane2,proto3" json:"nodal_plane2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MomentTensorProduct) Reset()         { *m = MomentTensorProduct{} }
+func (m *MomentTensorProduct) String() string { return proto.CompactTextString(m) }
+func (*MomentTensorProduct) ProtoMessage()    {}
+func (*MomentTensorProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{7}
+}
+
+func (m *MomentTensorProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MomentTensorProduct.Unmarshal(m, b)
+}
+func (m *MomentTensorProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MomentTensorProduct.Marshal(b, m, deterministic)
+}
+func (m *MomentTensorProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MomentTensorProduct.Merge(m, src)
+}
+func (m *MomentTensorProduct) XXX_Size() int {
+	return xxx_messageInfo_MomentTensorProduct.Size(m)
+}
+func (m *MomentTensorProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_MomentTensorProduct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MomentTensorProduct proto.InternalMessageInfo
+
+func (m *MomentTensorProduct) GetInfo() *ProductInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *MomentTensorProduct) GetDerivedMagnitude() float32 {
+	if m != nil {
+		return m.DerivedMagnitude
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetDerivedMagnitudeType() string {
+	if m != nil {
+		return m.DerivedMagnitudeType
+	}
+	return ""
+}
+
+func (m *MomentTensorProduct) GetDerivedHeight() int32 {
+	if m != nil {
+		return m.DerivedHeight
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetScalarMoment() float64 {
+	if m != nil {
+		return m.ScalarMoment
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetTensorMrr() float64 {
+	if m != nil {
+		return m.TensorMrr
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetTensorMtt() float64 {
+	if m != nil {
+		return m.TensorMtt
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetTensorMpp() float64 {
+	if m != nil {
+		return m.TensorMpp
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetTensorMrt() float64 {
+	if m != nil {
+		return m.TensorMrt
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetTensorMrp() float64 {
+	if m != nil {
+		return m.TensorMrp
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetTensorMtp() float64 {
+	if m != nil {
+		return m.TensorMtp
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetPercentDoubleCouple() float32 {
+	if m != nil {
+		return m.PercentDoubleCouple
+	}
+	return 0
+}
+
+func (m *MomentTensorProduct) GetNodalPlane1() *NodalPlane {
+	if m != nil {
+		return m.NodalPlane1
+	}
+	return nil
+}
+
+func (m *MomentTensorProduct) GetNodalPlane2() *NodalPlane {
+	if m != nil {
+		return m.NodalPlane2
+	}
+	return nil
+}
+
+// FocalMechanismProduct is a summary of the "focal-mechanism" product.
+type FocalMechanismProduct struct {
+	Info                 *ProductInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	NodalPlane1          *NodalPlane  `protobuf:"bytes,2,opt,name=nodal_plane1,json=nodalPlane1,proto3" json:"nodal_plane1,omitempty"`
+	NodalPlane2          *NodalPlane  `protobuf:"bytes,3,opt,name=nodal_plane2,json=nodalPlane2,proto3" json:"nodal_plane2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *FocalMechanismProduct) Reset()         { *m = FocalMechanismProduct{} }
+func (m *FocalMechanismProduct) String() string { return proto.CompactTextString(m) }
+func (*FocalMechanismProduct) ProtoMessage()    {}
+func (*FocalMechanismProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{8}
+}
+
+func (m *FocalMechanismProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FocalMechanismProduct.Unmarshal(m, b)
+}
+func (m *FocalMechanismProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FocalMechanismProduct.Marshal(b, m, deterministic)
+}
+func (m *FocalMechanismProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FocalMechanismProduct.Merge(m, src)
+}
+func (m *FocalMechanismProduct) XXX_Size() int {
+	return xxx_messageInfo_FocalMechanismProduct.Size(m)
+}
+func (m *FocalMechanismProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_FocalMechanismProduct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FocalMechanismProduct proto.InternalMessageInfo
+
+func (m *FocalMechanismProduct) GetInfo() *ProductInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *FocalMechanismProduct) GetNodalPlane1() *NodalPlane {
+	if m != nil {
+		return m.NodalPlane1
+	}
+	return nil
+}
+
+func (m *FocalMechanismProduct) GetNodalPlane2() *NodalPlane {
+	if m != nil {
+		return m.NodalPlane2
+	}
+	return nil
+}
+
+// ShakeMapProduct is a summary of the "shakemap" product.
+type ShakeMapProduct struct {
+	Info *ProductInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// Maximum instrumental intensity (MMI), peak ground acceleration (%g) and
+	// peak ground velocity (cm/s).
+	MaxMmi float32 `protobuf:"fixed32,2,opt,name=max_mmi,json=maxMmi,proto3" json:"max_mmi,omitempty"`
+	MaxPga float32 `protobuf:"fixed32,3,opt,name=max_pga,json=maxPga,proto3" json:"max_pga,omitempty"`
+	MaxPgv float32 `protobuf:"fixed32,4,opt,name=max_pgv,json=maxPgv,proto3" json:"max_pgv,omitempty"`
+	// Map status. Typical values: "RELEASED", "REVIEWED".
+	MapStatus            string   `protobuf:"bytes,5,opt,name=map_status,json=mapStatus,proto3" json:"map_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShakeMapProduct) Reset()         { *m = ShakeMapProduct{} }
+func (m *ShakeMapProduct) String() string { return proto.CompactTextString(m) }
+func (*ShakeMapProduct) ProtoMessage()    {}
+func (*ShakeMapProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{9}
+}
+
+func (m *ShakeMapProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShakeMapProduct.Unmarshal(m, b)
+}
+func (m *ShakeMapProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShakeMapProduct.Marshal(b, m, deterministic)
+}
+func (m *ShakeMapProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShakeMapProduct.Merge(m, src)
+}
+func (m *ShakeMapProduct) XXX_Size() int {
+	return xxx_messageInfo_ShakeMapProduct.Size(m)
+}
+func (m *ShakeMapProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShakeMapProduct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShakeMapProduct proto.InternalMessageInfo
+
+func (m *ShakeMapProduct) GetInfo() *ProductInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *ShakeMapProduct) GetMaxMmi() float32 {
+	if m != nil {
+		return m.MaxMmi
+	}
+	return 0
+}
+
+func (m *ShakeMapProduct) GetMaxPga() float32 {
+	if m != nil {
+		return m.MaxPga
+	}
+	return 0
+}
+
+func (m *ShakeMapProduct) GetMaxPgv() float32 {
+	if m != nil {
+		return m.MaxPgv
+	}
+	return 0
+}
+
+func (m *ShakeMapProduct) GetMapStatus() string {
+	if m != nil {
+		return m.MapStatus
+	}
+	return ""
+}
+
+// PagerProduct is a summary of the "losspager" product.
+type PagerProduct struct {
+	Info *ProductInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// Alert level of PAGER.
+	Alert Alert `protobuf:"varint,2,opt,name=alert,proto3,enum=quake.api.v1.Alert" json:"alert,omitempty"`
+	// Maximum estimated intensity (MMI).
+	MaxMmi               float32  `protobuf:"fixed32,3,opt,name=max_mmi,json=maxMmi,proto3" json:"max_mmi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PagerProduct) Reset()         { *m = PagerProduct{} }
+func (m *PagerProduct) String() string { return proto.CompactTextString(m) }
+func (*PagerProduct) ProtoMessage()    {}
+func (*PagerProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{10}
+}
+
+func (m *PagerProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PagerProduct.Unmarshal(m, b)
+}
+func (m *PagerProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PagerProduct.Marshal(b, m, deterministic)
+}
+func (m *PagerProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PagerProduct.Merge(m, src)
+}
+func (m *PagerProduct) XXX_Size() int {
+	return xxx_messageInfo_PagerProduct.Size(m)
+}
+func (m *PagerProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_PagerProduct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PagerProduct proto.InternalMessageInfo
+
+func (m *PagerProduct) GetInfo() *ProductInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *PagerProduct) GetAlert() Alert {
+	if m != nil {
+		return m.Alert
+	}
+	return Alert_ALERT_UNSPECIFIED
+}
+
+func (m *PagerProduct) GetMaxMmi() float32 {
+	if m != nil {
+		return m.MaxMmi
+	}
+	return 0
+}
+
+// DyfiProduct is a summary of the "dyfi" product.
+type DyfiProduct struct {
+	Info *ProductInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// Maximum reported intensity (CDI).
+	MaxMmi float32 `protobuf:"fixed32,2,opt,name=max_mmi,json=maxMmi,proto3" json:"max_mmi,omitempty"`
+	// Number of felt reports.
+	NumResponses         int32    `protobuf:"varint,3,opt,name=num_responses,json=numResponses,proto3" json:"num_responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DyfiProduct) Reset()         { *m = DyfiProduct{} }
+func (m *DyfiProduct) String() string { return proto.CompactTextString(m) }
+func (*DyfiProduct) ProtoMessage()    {}
+func (*DyfiProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{11}
+}
+
+func (m *DyfiProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DyfiProduct.Unmarshal(m, b)
+}
+func (m *DyfiProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DyfiProduct.Marshal(b, m, deterministic)
+}
+func (m *DyfiProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DyfiProduct.Merge(m, src)
+}
+func (m *DyfiProduct) XXX_Size() int {
+	return xxx_messageInfo_DyfiProduct.Size(m)
+}
+func (m *DyfiProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_DyfiProduct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DyfiProduct proto.InternalMessageInfo
+
+func (m *DyfiProduct) GetInfo() *ProductInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *DyfiProduct) GetMaxMmi() float32 {
+	if m != nil {
+		return m.MaxMmi
+	}
+	return 0
+}
+
+func (m *DyfiProduct) GetNumResponses() int32 {
+	if m != nil {
+		return m.NumResponses
+	}
+	return 0
+}
+
+// EarthquakeEvent tells that an earthquake was added, updated or deleted.
+type EarthquakeEvent struct {
+	// Type of the event.
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=quake.api.v1.EventType" json:"type,omitempty"`
+	// Feature as an Earthquake (as it was after the event).
+	Feature              *Earthquake `protobuf:"bytes,2,opt,name=feature,proto3" json:"feature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EarthquakeEvent) Reset()         { *m = EarthquakeEvent{} }
+func (m *EarthquakeEvent) String() string { return proto.CompactTextString(m) }
+func (*EarthquakeEvent) ProtoMessage()    {}
+func (*EarthquakeEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{12}
+}
+
+func (m *EarthquakeEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EarthquakeEvent.Unmarshal(m, b)
+}
+func (m *EarthquakeEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EarthquakeEvent.Marshal(b, m, deterministic)
+}
+func (m *EarthquakeEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EarthquakeEvent.Merge(m, src)
+}
+func (m *EarthquakeEvent) XXX_Size() int {
+	return xxx_messageInfo_EarthquakeEvent.Size(m)
+}
+func (m *EarthquakeEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_EarthquakeEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EarthquakeEvent proto.InternalMessageInfo
+
+func (m *EarthquakeEvent) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *EarthquakeEvent) GetFeature() *Earthquake {
+	if m != nil {
+		return m.Feature
+	}
+	return nil
+}
+
+// EarthquakeMetadata contains meta data for a set of earthquakes.
+type EarthquakeMetadata struct {
+	// USGS docs: "Time (seconds) when the feed was most recently updated".
+	// Time is UTC time since Unix epoch 1970-01-01T00:00:00Z.
+	GeneratedTime int64 `protobuf:"varint,1,opt,name=generated_time,json=generatedTime,proto3" json:"generated_time,omitempty"`
+	// USGS docs: "Url of the feed".
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// USGS docs: "The title of the feed". Typical values:
+	// “USGS Magnitude 1+ Earthquakes, Past Day”,
+	// “USGS Magnitude 4.5+ Earthquakes, Past Month”
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// USGS docs: "Version of API that generated feed".
+	Api string `protobuf:"bytes,4,opt,name=api,proto3" json:"api,omitempty"`
+	// USGS docs: "Number of earthquakes in feed".
+	Count int32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// USGS docs: "HTTP status code of response".
+	HttpStatus           string   `protobuf:"bytes,6,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EarthquakeMetadata) Reset()         { *m = EarthquakeMetadata{} }
+func (m *EarthquakeMetadata) String() string { return proto.CompactTextString(m) }
+func (*EarthquakeMetadata) ProtoMessage()    {}
+func (*EarthquakeMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{13}
+}
+
+func (m *EarthquakeMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EarthquakeMetadata.Unmarshal(m, b)
+}
+func (m *EarthquakeMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EarthquakeMetadata.Marshal(b, m, deterministic)
+}
+func (m *EarthquakeMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EarthquakeMetadata.Merge(m, src)
+}
+func (m *EarthquakeMetadata) XXX_Size() int {
+	return xxx_messageInfo_EarthquakeMetadata.Size(m)
+}
+func (m *EarthquakeMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_EarthquakeMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EarthquakeMetadata proto.InternalMessageInfo
+
+func (m *EarthquakeMetadata) GetGeneratedTime() int64 {
+	if m != nil {
+		return m.GeneratedTime
+	}
+	return 0
+}
+
+func (m *EarthquakeMetadata) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *EarthquakeMetadata) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *EarthquakeMetadata) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *EarthquakeMetadata) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *EarthquakeMetadata) GetHttpStatus() string {
+	if m != nil {
+		return m.HttpStatus
+	}
+	return ""
+}
+
+// GeoBoundsE7 is a geographic bounding box (WGS84 latitude and longitude
+// are in E7 format, height is centimeters with negative values meaning depth).
+// The E7 format with 32 bit ints is used to optimize wire transfer. When
+// filtering by bounds the height range applies too, unless both min_height and
+// max_height are 0.
+type GeoBoundsE7 struct {
+	MinLatitude          int32    `protobuf:"fixed32,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude         int32    `protobuf:"fixed32,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MinHeight            int32    `protobuf:"zigzag32,3,opt,name=min_height,json=minHeight,proto3" json:"min_height,omitempty"`
+	MaxLatitude          int32    `protobuf:"fixed32,4,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude         int32    `protobuf:"fixed32,5,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
+	MaxHeight            int32    `protobuf:"zigzag32,6,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GeoBoundsE7) Reset()         { *m = GeoBoundsE7{} }
+func (m *GeoBoundsE7) String() string { return proto.CompactTextString(m) }
+func (*GeoBoundsE7) ProtoMessage()    {}
+func (*GeoBoundsE7) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{14}
+}
+
+func (m *GeoBoundsE7) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeoBoundsE7.Unmarshal(m, b)
+}
+func (m *GeoBoundsE7) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeoBoundsE7.Marshal(b, m, deterministic)
+}
+func (m *GeoBoundsE7) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeoBoundsE7.Merge(m, src)
+}
+func (m *GeoBoundsE7) XXX_Size() int {
+	return xxx_messageInfo_GeoBoundsE7.Size(m)
+}
+func (m *GeoBoundsE7) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeoBoundsE7.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeoBoundsE7 proto.InternalMessageInfo
+
+func (m *GeoBoundsE7) GetMinLatitude() int32 {
+	if m != nil {
+		return m.MinLatitude
+	}
+	return 0
+}
+
+func (m *GeoBoundsE7) GetMinLongitude() int32 {
+	if m != nil {
+		return m.MinLongitude
+	}
+	return 0
+}
+
+func (m *GeoBoundsE7) GetMinHeight() int32 {
+	if m != nil {
+		return m.MinHeight
+	}
+	return 0
+}
+
+func (m *GeoBoundsE7) GetMaxLatitude() int32 {
+	if m != nil {
+		return m.MaxLatitude
+	}
+	return 0
+}
+
+func (m *GeoBoundsE7) GetMaxLongitude() int32 {
+	if m != nil {
+		return m.MaxLongitude
+	}
+	return 0
+}
+
+func (m *GeoBoundsE7) GetMaxHeight() int32 {
+	if m != nil {
+		return m.MaxHeight
+	}
+	return 0
+}
+
+// GeoRingE7 is a closed ring of geographic points (height is not used). Edges
+// between points are straight lines on latitude and longitude coordinates
+// (like on GeoJSON), an edge crosses the antimeridian when longitudes of its
+// points differ more than 180 degrees. The first point may be repeated as the
+// last point. A ring around a pole must have the pole as points of the ring.
+type GeoRingE7 struct {
+	Points               []*GeoPointE7 `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GeoRingE7) Reset()         { *m = GeoRingE7{} }
+func (m *GeoRingE7) String() string { return proto.CompactTextString(m) }
+func (*GeoRingE7) ProtoMessage()    {}
+func (*GeoRingE7) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{15}
+}
+
+func (m *GeoRingE7) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeoRingE7.Unmarshal(m, b)
+}
+func (m *GeoRingE7) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeoRingE7.Marshal(b, m, deterministic)
+}
+func (m *GeoRingE7) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeoRingE7.Merge(m, src)
+}
+func (m *GeoRingE7) XXX_Size() int {
+	return xxx_messageInfo_GeoRingE7.Size(m)
+}
+func (m *GeoRingE7) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeoRingE7.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeoRingE7 proto.InternalMessageInfo
+
+func (m *GeoRingE7) GetPoints() []*GeoPointE7 {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+// GeoPolygonE7 is a polygon with an exterior ring and optional interior rings
+// (holes).
+type GeoPolygonE7 struct {
+	Exterior             *GeoRingE7   `protobuf:"bytes,1,opt,name=exterior,proto3" json:"exterior,omitempty"`
+	Holes                []*GeoRingE7 `protobuf:"bytes,2,rep,name=holes,proto3" json:"holes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GeoPolygonE7) Reset()         { *m = GeoPolygonE7{} }
+func (m *GeoPolygonE7) String() string { return proto.CompactTextString(m) }
+func (*GeoPolygonE7) ProtoMessage()    {}
+func (*GeoPolygonE7) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{16}
+}
+
+func (m *GeoPolygonE7) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeoPolygonE7.Unmarshal(m, b)
+}
+func (m *GeoPolygonE7) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeoPolygonE7.Marshal(b, m, deterministic)
+}
+func (m *GeoPolygonE7) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeoPolygonE7.Merge(m, src)
+}
+func (m *GeoPolygonE7) XXX_Size() int {
+	return xxx_messageInfo_GeoPolygonE7.Size(m)
+}
+func (m *GeoPolygonE7) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeoPolygonE7.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeoPolygonE7 proto.InternalMessageInfo
+
+func (m *GeoPolygonE7) GetExterior() *GeoRingE7 {
+	if m != nil {
+		return m.Exterior
+	}
+	return nil
+}
+
+func (m *GeoPolygonE7) GetHoles() []*GeoRingE7 {
+	if m != nil {
+		return m.Holes
+	}
+	return nil
+}
+
+// GeoMultiPolygonE7 is an area of one or more polygons.
+type GeoMultiPolygonE7 struct {
+	Polygons             []*GeoPolygonE7 `protobuf:"bytes,1,rep,name=polygons,proto3" json:"polygons,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GeoMultiPolygonE7) Reset()         { *m = GeoMultiPolygonE7{} }
+func (m *GeoMultiPolygonE7) String() string { return proto.CompactTextString(m) }
+func (*GeoMultiPolygonE7) ProtoMessage()    {}
+func (*GeoMultiPolygonE7) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{17}
+}
+
+func (m *GeoMultiPolygonE7) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeoMultiPolygonE7.Unmarshal(m, b)
+}
+func (m *GeoMultiPolygonE7) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeoMultiPolygonE7.Marshal(b, m, deterministic)
+}
+func (m *GeoMultiPolygonE7) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeoMultiPolygonE7.Merge(m, src)
+}
+func (m *GeoMultiPolygonE7) XXX_Size() int {
+	return xxx_messageInfo_GeoMultiPolygonE7.Size(m)
+}
+func (m *GeoMultiPolygonE7) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeoMultiPolygonE7.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeoMultiPolygonE7 proto.InternalMessageInfo
+
+func (m *GeoMultiPolygonE7) GetPolygons() []*GeoPolygonE7 {
+	if m != nil {
+		return m.Polygons
+	}
+	return nil
+}
+
+// GeoPointE7 is a geographic point (WGS84 latitude and longitude are
+// in E7 format and height is centimeters with negative values meaning depth).
+// The E7 format with 32 bit ints is used to optimize wire transfer.
+type GeoPointE7 struct {
+	Latitude             int32    `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            int32    `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Height               int32    `protobuf:"zigzag32,3,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GeoPointE7) Reset()         { *m = GeoPointE7{} }
+func (m *GeoPointE7) String() string { return proto.CompactTextString(m) }
+func (*GeoPointE7) ProtoMessage()    {}
+func (*GeoPointE7) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d542a431c78f4780, []int{18}
 }
 
 func (m *GeoPointE7) XXX_Unmarshal(b []byte) error {
@@ -774,78 +1792,152 @@ func init() {
 	proto.RegisterEnum("quake.api.v1.Alert", Alert_name, Alert_value)
 	proto.RegisterEnum("quake.api.v1.Status", Status_name, Status_value)
 	proto.RegisterEnum("quake.api.v1.Type", Type_name, Type_value)
+	proto.RegisterEnum("quake.api.v1.EventType", EventType_name, EventType_value)
 	proto.RegisterType((*EarthquakeCollection)(nil), "quake.api.v1.EarthquakeCollection")
 	proto.RegisterType((*Earthquake)(nil), "quake.api.v1.Earthquake")
 	proto.RegisterType((*EarthquakeDetails)(nil), "quake.api.v1.EarthquakeDetails")
+	proto.RegisterType((*EarthquakeProducts)(nil), "quake.api.v1.EarthquakeProducts")
+	proto.RegisterType((*ProductInfo)(nil), "quake.api.v1.ProductInfo")
+	proto.RegisterType((*OriginProduct)(nil), "quake.api.v1.OriginProduct")
+	proto.RegisterType((*NodalPlane)(nil), "quake.api.v1.NodalPlane")
+	proto.RegisterType((*MomentTensorProduct)(nil), "quake.api.v1.MomentTensorProduct")
+	proto.RegisterType((*FocalMechanismProduct)(nil), "quake.api.v1.FocalMechanismProduct")
+	proto.RegisterType((*ShakeMapProduct)(nil), "quake.api.v1.ShakeMapProduct")
+	proto.RegisterType((*PagerProduct)(nil), "quake.api.v1.PagerProduct")
+	proto.RegisterType((*DyfiProduct)(nil), "quake.api.v1.DyfiProduct")
+	proto.RegisterType((*EarthquakeEvent)(nil), "quake.api.v1.EarthquakeEvent")
 	proto.RegisterType((*EarthquakeMetadata)(nil), "quake.api.v1.EarthquakeMetadata")
 	proto.RegisterType((*GeoBoundsE7)(nil), "quake.api.v1.GeoBoundsE7")
+	proto.RegisterType((*GeoRingE7)(nil), "quake.api.v1.GeoRingE7")
+	proto.RegisterType((*GeoPolygonE7)(nil), "quake.api.v1.GeoPolygonE7")
+	proto.RegisterType((*GeoMultiPolygonE7)(nil), "quake.api.v1.GeoMultiPolygonE7")
 	proto.RegisterType((*GeoPointE7)(nil), "quake.api.v1.GeoPointE7")
 }
 
 func init() { proto.RegisterFile("quake/api/v1/quake.proto", fileDescriptor_d542a431c78f4780) }
 
 var fileDescriptor_d542a431c78f4780 = []byte{
-	// 981 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xdf, 0x6e, 0x23, 0xb5,
-	0x1b, 0xfd, 0xcd, 0xe4, 0x4f, 0x93, 0x2f, 0x69, 0x32, 0x71, 0xf3, 0x5b, 0x79, 0x11, 0x68, 0x43,
-	0x10, 0x4b, 0xa8, 0xa0, 0x55, 0x0b, 0xd2, 0x0a, 0x89, 0x9b, 0xb4, 0xf1, 0x76, 0x2b, 0xb2, 0x6d,
-	0xd7, 0x4d, 0x58, 0x95, 0x0b, 0x22, 0x37, 0xe3, 0xa4, 0xd6, 0x66, 0xfe, 0x30, 0xe3, 0x29, 0x5d,
-	0xde, 0x87, 0x3b, 0x5e, 0x81, 0x27, 0xe1, 0x25, 0x78, 0x04, 0xe4, 0xcf, 0x93, 0x64, 0xd3, 0x68,
-	0xef, 0xfc, 0x9d, 0x73, 0x3e, 0x1f, 0xfb, 0x78, 0xec, 0x01, 0xfa, 0x5b, 0x26, 0xde, 0xc9, 0x43,
-	0x11, 0xab, 0xc3, 0xfb, 0xa3, 0x43, 0x2c, 0x0e, 0xe2, 0x24, 0xd2, 0x11, 0xa9, 0xdb, 0x42, 0xc4,
-	0xea, 0xe0, 0xfe, 0xa8, 0xfb, 0xb7, 0x03, 0x6d, 0x26, 0x12, 0x7d, 0x87, 0xe8, 0x69, 0xb4, 0x58,
-	0xc8, 0xa9, 0x56, 0x51, 0x48, 0x7e, 0x84, 0x4a, 0x20, 0xb5, 0xf0, 0x85, 0x16, 0xd4, 0xe9, 0x38,
-	0xbd, 0xda, 0x71, 0xe7, 0xe0, 0xc3, 0xce, 0x83, 0x75, 0xd7, 0xeb, 0x5c, 0xc7, 0x57, 0x1d, 0xe4,
-	0x08, 0xca, 0xb7, 0x51, 0x16, 0xfa, 0x29, 0x75, 0xb1, 0xf7, 0xe9, 0x66, 0xef, 0x99, 0x8c, 0x4e,
-	0x90, 0x66, 0x2f, 0x78, 0x2e, 0x24, 0xdf, 0x43, 0x65, 0x26, 0x85, 0xce, 0x12, 0x99, 0xd2, 0x42,
-	0xa7, 0xd0, 0xab, 0x1d, 0xd3, 0x8f, 0x19, 0xf2, 0x95, 0xb2, 0xfb, 0xaf, 0x0b, 0xb0, 0x26, 0x48,
-	0x03, 0x5c, 0xe5, 0xe3, 0x7a, 0xab, 0xdc, 0x55, 0xbe, 0x99, 0x34, 0x8e, 0x52, 0x65, 0x76, 0x94,
-	0xaf, 0x84, 0x6e, 0xad, 0xe4, 0x2a, 0x52, 0xa1, 0x66, 0x2f, 0xf8, 0x4a, 0x49, 0x3e, 0x85, 0x6a,
-	0x20, 0xe6, 0xa1, 0xd2, 0x99, 0x2f, 0x69, 0xa1, 0xe3, 0xf4, 0x5c, 0xbe, 0x06, 0x48, 0x1b, 0x4a,
-	0xf1, 0x42, 0x4c, 0x25, 0x2d, 0xa2, 0x8d, 0x2d, 0x08, 0x81, 0xa2, 0x56, 0x81, 0xa4, 0xa5, 0x8e,
-	0xd3, 0x2b, 0x70, 0x1c, 0x93, 0xcf, 0xa1, 0x9e, 0xc5, 0xbe, 0xd0, 0xd2, 0x9f, 0x20, 0x57, 0x46,
-	0xae, 0x96, 0x63, 0x23, 0x23, 0xf9, 0x0a, 0x9a, 0x86, 0xfa, 0x23, 0x0a, 0xe5, 0x24, 0x9a, 0xcd,
-	0x52, 0xa9, 0xe9, 0x4e, 0xc7, 0xe9, 0xb5, 0x78, 0x63, 0x09, 0x5f, 0x22, 0x4a, 0xbe, 0x86, 0x92,
-	0x58, 0xc8, 0x44, 0xd3, 0x4a, 0xc7, 0xe9, 0x35, 0x8e, 0xf7, 0x36, 0xb7, 0xd1, 0x37, 0x14, 0xb7,
-	0x0a, 0xd2, 0x85, 0x7a, 0xaa, 0xe6, 0xa1, 0x9a, 0xa9, 0xa9, 0x08, 0xa7, 0x92, 0x56, 0x3b, 0x4e,
-	0xaf, 0xc4, 0x37, 0x30, 0xf2, 0x03, 0xec, 0xf8, 0x52, 0x0b, 0xb5, 0x48, 0x29, 0x60, 0x2e, 0xcf,
-	0x3e, 0x16, 0xf6, 0xc0, 0xca, 0xf8, 0x52, 0xdf, 0xfd, 0xb3, 0x08, 0xad, 0x2d, 0x7a, 0x2b, 0x79,
-	0x0f, 0x0a, 0x59, 0xb2, 0xc0, 0xd0, 0xab, 0xdc, 0x0c, 0xc9, 0x73, 0x68, 0xda, 0x29, 0x26, 0x33,
-	0x29, 0xfd, 0x89, 0x61, 0x0b, 0xc8, 0xee, 0x5a, 0xf8, 0xa5, 0x94, 0xfe, 0x38, 0x59, 0x98, 0x24,
-	0x67, 0x72, 0xa1, 0x31, 0xde, 0x12, 0xc7, 0x31, 0xf9, 0x16, 0x48, 0x22, 0xe3, 0x28, 0x31, 0x51,
-	0xaa, 0x50, 0xcb, 0x30, 0x55, 0xfa, 0x3d, 0x66, 0xed, 0xf2, 0xd6, 0x92, 0x39, 0x5f, 0x12, 0xe4,
-	0x10, 0xf6, 0x64, 0xaa, 0x55, 0x20, 0x36, 0xf5, 0x65, 0xd4, 0x93, 0x15, 0xb5, 0x6e, 0xf8, 0x06,
-	0xca, 0xa9, 0x16, 0x3a, 0x4b, 0x31, 0xfd, 0xc6, 0x71, 0x7b, 0x33, 0x8d, 0x6b, 0xe4, 0x78, 0xae,
-	0x21, 0x14, 0x76, 0x74, 0x9a, 0x85, 0x22, 0x50, 0x78, 0x1a, 0x15, 0xbe, 0x2c, 0x0d, 0x13, 0x4a,
-	0xfd, 0x7b, 0x94, 0xbc, 0xc3, 0xd4, 0xab, 0x7c, 0x59, 0x9a, 0x5d, 0x4d, 0x23, 0x5f, 0x62, 0xda,
-	0x55, 0x8e, 0x63, 0x93, 0x91, 0xf2, 0x53, 0x5a, 0xb3, 0x19, 0x29, 0x1f, 0x67, 0x4e, 0xa3, 0x2c,
-	0x99, 0xca, 0x94, 0xd6, 0x6d, 0x7f, 0x5e, 0x92, 0x2f, 0x60, 0x37, 0x4e, 0x22, 0x3f, 0x9b, 0xea,
-	0x89, 0x7e, 0x1f, 0xcb, 0x94, 0xee, 0x22, 0x5f, 0xcf, 0xc1, 0x91, 0xc1, 0xcc, 0x84, 0x61, 0xaa,
-	0x69, 0x03, 0x93, 0x33, 0x43, 0x63, 0xeb, 0x07, 0x2a, 0xa4, 0x4d, 0xdc, 0x3a, 0x8e, 0x8d, 0x2a,
-	0x09, 0x52, 0xea, 0x21, 0x64, 0x86, 0x06, 0x99, 0x8b, 0x98, 0xb6, 0x2c, 0x32, 0x17, 0x31, 0x79,
-	0x0a, 0x95, 0x40, 0xcc, 0xd1, 0x8a, 0x12, 0xbb, 0x92, 0x40, 0xcc, 0x8d, 0x0b, 0x79, 0x0e, 0x45,
-	0x84, 0xf7, 0x30, 0x29, 0xb2, 0x99, 0x94, 0x51, 0x70, 0xe4, 0xbb, 0x7f, 0x39, 0x40, 0xb6, 0x1f,
-	0x09, 0xf2, 0x25, 0x34, 0xe6, 0x32, 0x94, 0xc9, 0xfa, 0x5a, 0x38, 0x78, 0x2d, 0x76, 0x57, 0x28,
-	0x5e, 0x8c, 0xed, 0xef, 0xa7, 0x0d, 0x25, 0xad, 0xf4, 0x42, 0xe6, 0x5f, 0x8d, 0x2d, 0x8c, 0x4e,
-	0xc4, 0x2a, 0xbf, 0x8b, 0x66, 0x68, 0x74, 0xd3, 0x28, 0x0b, 0x35, 0x7e, 0x1e, 0x25, 0x6e, 0x0b,
-	0xf2, 0x0c, 0x6a, 0x77, 0x5a, 0xc7, 0x93, 0xfc, 0x98, 0xcb, 0xa8, 0x07, 0x03, 0xd9, 0xc3, 0xed,
-	0xfe, 0xe3, 0x40, 0xed, 0x83, 0x77, 0xc9, 0x5c, 0xde, 0x40, 0x85, 0x93, 0x85, 0xd0, 0xf6, 0x1d,
-	0x30, 0xab, 0x6c, 0xf2, 0x5a, 0xa0, 0xc2, 0x61, 0x0e, 0x99, 0x33, 0x41, 0x49, 0x14, 0xce, 0xad,
-	0xc6, 0x45, 0x8d, 0xe9, 0x1b, 0x2e, 0x31, 0xf2, 0x19, 0x80, 0x11, 0xdd, 0x49, 0x35, 0xbf, 0xd3,
-	0xb8, 0xf6, 0x16, 0xaf, 0x06, 0x2a, 0x7c, 0x85, 0x00, 0xda, 0x88, 0x87, 0xb5, 0x4d, 0x31, 0xb7,
-	0x11, 0x0f, 0x1b, 0x36, 0x46, 0xb2, 0xb2, 0x29, 0xe5, 0x36, 0xe2, 0x61, 0xd3, 0x46, 0x3c, 0x2c,
-	0x6d, 0xca, 0xb9, 0x8d, 0x78, 0xb0, 0x36, 0xdd, 0x5f, 0x01, 0xd6, 0x4f, 0x1d, 0xf9, 0x04, 0x2a,
-	0x8f, 0xf6, 0xb5, 0xaa, 0xcd, 0xe3, 0xf7, 0x78, 0x43, 0x6b, 0x80, 0x3c, 0x81, 0xf2, 0xc6, 0x4e,
-	0xf2, 0x6a, 0xff, 0x16, 0x4a, 0xf8, 0x06, 0x91, 0xff, 0x43, 0xab, 0x3f, 0x64, 0x7c, 0x34, 0x19,
-	0x5f, 0x5c, 0x5f, 0xb1, 0xd3, 0xf3, 0x97, 0xe7, 0x6c, 0xe0, 0xfd, 0x8f, 0xec, 0x42, 0xd5, 0xc2,
-	0x9c, 0x0d, 0x3c, 0x87, 0x78, 0x50, 0xb7, 0xe5, 0x25, 0xef, 0x5f, 0x9c, 0x31, 0xcf, 0x5d, 0x23,
-	0x37, 0x6c, 0x38, 0xbc, 0x7c, 0xeb, 0x15, 0x48, 0x13, 0x6a, 0x16, 0x39, 0xe3, 0x8c, 0x5d, 0x78,
-	0xc5, 0xfd, 0x09, 0x94, 0xed, 0x59, 0x91, 0x27, 0x40, 0xae, 0x47, 0xfd, 0xd1, 0xf8, 0xfa, 0x91,
-	0x4b, 0x1b, 0xbc, 0x1c, 0xef, 0x8f, 0x47, 0x97, 0xaf, 0xfb, 0xa3, 0xf3, 0x53, 0xcf, 0x21, 0x7b,
-	0xd0, 0xcc, 0x51, 0xce, 0x7e, 0x3e, 0x67, 0x6f, 0xd9, 0xc0, 0x73, 0x09, 0x81, 0x46, 0x0e, 0x0e,
-	0xd8, 0x90, 0x8d, 0xd8, 0xc0, 0x2b, 0xec, 0x9f, 0x40, 0x11, 0xbf, 0xf0, 0x36, 0x78, 0xa3, 0x9b,
-	0x2b, 0xf6, 0x68, 0xf2, 0x3d, 0x68, 0x22, 0xca, 0xfa, 0x7c, 0xf4, 0xea, 0xcd, 0xb8, 0xff, 0x13,
-	0xf3, 0x1c, 0xb3, 0x48, 0x04, 0xdf, 0x8c, 0xfb, 0x9c, 0xdf, 0x78, 0xee, 0x49, 0xf1, 0x17, 0xf7,
-	0xfe, 0xe8, 0xb6, 0x8c, 0xff, 0xda, 0xef, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x1b, 0xb8, 0x04,
-	0x2a, 0x87, 0x07, 0x00, 0x00,
+	// 1949 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x5b, 0x73, 0x1b, 0xb7,
+	0x15, 0xee, 0xf2, 0x26, 0xf2, 0xf0, 0x2a, 0x48, 0x96, 0x37, 0x6e, 0x33, 0x61, 0xe8, 0x49, 0xa2,
+	0x38, 0xb5, 0x1d, 0xd1, 0x99, 0x7a, 0x32, 0x6d, 0x1f, 0x68, 0x73, 0xad, 0xa8, 0x11, 0x2d, 0x05,
+	0xa2, 0x92, 0x71, 0x1f, 0xba, 0x03, 0x71, 0x41, 0x12, 0xa3, 0xbd, 0x15, 0x0b, 0x2a, 0x92, 0xdf,
+	0xfa, 0x77, 0x3a, 0xfd, 0x03, 0x7d, 0xe8, 0x4c, 0xa7, 0xff, 0xa2, 0x93, 0x9f, 0xd2, 0x97, 0x0e,
+	0x2e, 0xbb, 0xe4, 0x52, 0x74, 0x5a, 0xb5, 0x79, 0x03, 0xbe, 0xf3, 0x1d, 0x9c, 0x03, 0x9c, 0x0b,
+	0xb0, 0x0b, 0xf6, 0x1f, 0x17, 0xe4, 0x92, 0x3e, 0x25, 0x31, 0x7b, 0x7a, 0x75, 0xf0, 0x54, 0x4d,
+	0x9e, 0xc4, 0x3c, 0x12, 0x11, 0x6a, 0xe8, 0x09, 0x89, 0xd9, 0x93, 0xab, 0x83, 0xde, 0xdf, 0x2c,
+	0xd8, 0x75, 0x08, 0x17, 0x73, 0x85, 0xbe, 0x8c, 0x7c, 0x9f, 0x4e, 0x04, 0x8b, 0x42, 0xf4, 0x1b,
+	0xa8, 0x06, 0x54, 0x10, 0x8f, 0x08, 0x62, 0x5b, 0x5d, 0x6b, 0xbf, 0xde, 0xef, 0x3e, 0x59, 0xd5,
+	0x7c, 0xb2, 0xd4, 0x1a, 0x19, 0x1e, 0xce, 0x34, 0xd0, 0x01, 0x54, 0x2e, 0xa2, 0x45, 0xe8, 0x25,
+	0x76, 0x41, 0xe9, 0xbe, 0x97, 0xd7, 0x3d, 0xa4, 0xd1, 0x0b, 0x25, 0x76, 0x9e, 0x63, 0x43, 0x44,
+	0x5f, 0x40, 0x75, 0x4a, 0x89, 0x58, 0x70, 0x9a, 0xd8, 0xc5, 0x6e, 0x71, 0xbf, 0xde, 0xb7, 0xdf,
+	0x65, 0x10, 0x67, 0xcc, 0xde, 0x3f, 0x8a, 0x00, 0x4b, 0x01, 0x6a, 0x41, 0x81, 0x79, 0xca, 0xdf,
+	0x1a, 0x2e, 0x30, 0x4f, 0x2e, 0x1a, 0x47, 0x09, 0x93, 0x3b, 0x32, 0x9e, 0xd8, 0xb7, 0x3c, 0x39,
+	0x8d, 0x58, 0x28, 0x9c, 0xe7, 0x38, 0x63, 0xa2, 0x5f, 0x40, 0x2d, 0x20, 0xb3, 0x90, 0x89, 0x85,
+	0x47, 0xed, 0x62, 0xd7, 0xda, 0x2f, 0xe0, 0x25, 0x80, 0x76, 0xa1, 0x1c, 0xfb, 0x64, 0x42, 0xed,
+	0x92, 0x32, 0xa3, 0x27, 0x08, 0x41, 0x49, 0xb0, 0x80, 0xda, 0xe5, 0xae, 0xb5, 0x5f, 0xc4, 0x6a,
+	0x8c, 0x3e, 0x84, 0xc6, 0x22, 0xf6, 0x88, 0xa0, 0x9e, 0xab, 0x64, 0x15, 0x25, 0xab, 0x1b, 0x6c,
+	0x2c, 0x29, 0x9f, 0x40, 0x5b, 0x8a, 0xde, 0x46, 0x21, 0x75, 0xa3, 0xe9, 0x34, 0xa1, 0xc2, 0xde,
+	0xea, 0x5a, 0xfb, 0xdb, 0xb8, 0x95, 0xc2, 0x27, 0x0a, 0x45, 0x9f, 0x42, 0x99, 0xf8, 0x94, 0x0b,
+	0xbb, 0xda, 0xb5, 0xf6, 0x5b, 0xfd, 0x9d, 0xfc, 0x36, 0x06, 0x52, 0x84, 0x35, 0x03, 0xf5, 0xa0,
+	0x91, 0xb0, 0x59, 0xc8, 0xa6, 0x6c, 0x42, 0xc2, 0x09, 0xb5, 0x6b, 0x5d, 0x6b, 0xbf, 0x8c, 0x73,
+	0x18, 0xfa, 0x12, 0xb6, 0x3c, 0x2a, 0x08, 0xf3, 0x13, 0x1b, 0xd4, 0xb9, 0x7c, 0xf0, 0xae, 0xc3,
+	0x1e, 0x6a, 0x1a, 0x4e, 0xf9, 0xd2, 0x65, 0x8f, 0x25, 0x42, 0x2e, 0xe3, 0x06, 0x54, 0x50, 0x9e,
+	0xd8, 0xf5, 0xae, 0xb5, 0x6f, 0xe1, 0x56, 0x0a, 0x8f, 0x14, 0x2a, 0x89, 0x17, 0x94, 0x70, 0x16,
+	0xce, 0x5c, 0x8f, 0xce, 0x38, 0xa5, 0x89, 0xdd, 0xd0, 0x44, 0x03, 0x0f, 0x35, 0xda, 0xfb, 0x6b,
+	0x19, 0xb6, 0x6f, 0x19, 0xbc, 0x15, 0xcb, 0x0e, 0x14, 0x17, 0xdc, 0x57, 0x61, 0xac, 0x61, 0x39,
+	0x44, 0x1f, 0x43, 0x5b, 0x3b, 0xe5, 0x4e, 0x29, 0xf5, 0x5c, 0x29, 0x2d, 0x2a, 0x69, 0x53, 0xc3,
+	0xaf, 0x28, 0xf5, 0xce, 0xb9, 0x2f, 0x63, 0x33, 0xa5, 0xbe, 0x50, 0x01, 0x2b, 0x63, 0x35, 0x46,
+	0x8f, 0x01, 0x71, 0x1a, 0x47, 0x5c, 0x06, 0x87, 0x85, 0x82, 0x86, 0x09, 0x13, 0x37, 0x2a, 0x7a,
+	0x05, 0xbc, 0x9d, 0x4a, 0x8e, 0x52, 0x01, 0x7a, 0x0a, 0x3b, 0x34, 0x11, 0x2c, 0x20, 0x79, 0x7e,
+	0x45, 0xf1, 0x51, 0x26, 0x5a, 0x2a, 0xfc, 0x12, 0x2a, 0x89, 0x20, 0x62, 0x91, 0xa8, 0x78, 0xb6,
+	0xfa, 0xbb, 0xf9, 0xf3, 0x3d, 0x53, 0x32, 0x6c, 0x38, 0xc8, 0x86, 0x2d, 0x91, 0x2c, 0x42, 0x12,
+	0x30, 0x15, 0xdf, 0x2a, 0x4e, 0xa7, 0x52, 0x12, 0x52, 0xf1, 0x7d, 0xc4, 0x2f, 0x55, 0x1c, 0x6b,
+	0x38, 0x9d, 0xca, 0x5d, 0x4d, 0x22, 0x8f, 0xaa, 0xf8, 0xd5, 0xb0, 0x1a, 0xcb, 0x33, 0x62, 0x9e,
+	0x8e, 0x47, 0x0d, 0xcb, 0xa1, 0xd4, 0x4f, 0xa2, 0x05, 0x9f, 0x98, 0xc3, 0xaf, 0xe1, 0x74, 0x8a,
+	0x1e, 0x42, 0x33, 0xe6, 0x91, 0xb7, 0x98, 0x08, 0x57, 0xdc, 0xc4, 0x34, 0xb1, 0x9b, 0x4a, 0xde,
+	0x30, 0xe0, 0x58, 0x62, 0x72, 0xc1, 0x30, 0x11, 0x76, 0x4b, 0x9d, 0x9c, 0x1c, 0x4a, 0xb3, 0x5e,
+	0xc0, 0x42, 0xbb, 0xad, 0xb6, 0xae, 0xc6, 0x92, 0xc5, 0x83, 0xc4, 0xee, 0x28, 0x48, 0x0e, 0x25,
+	0x32, 0x23, 0xb1, 0xbd, 0xad, 0x91, 0x19, 0x89, 0xd1, 0x7b, 0x50, 0x0d, 0xc8, 0x4c, 0x99, 0xb2,
+	0x91, 0xf6, 0x24, 0x20, 0x33, 0x69, 0x05, 0x7d, 0x0c, 0x25, 0x05, 0xef, 0xa8, 0x93, 0x42, 0xf9,
+	0x93, 0x92, 0x0c, 0xac, 0xe4, 0xe8, 0x53, 0xe8, 0xcc, 0x23, 0xce, 0xde, 0x46, 0xa1, 0x20, 0xbe,
+	0x4b, 0x39, 0x8f, 0xb8, 0xbd, 0xab, 0x2c, 0xb4, 0x97, 0xb8, 0x23, 0x61, 0xf4, 0x01, 0xd4, 0x3d,
+	0x1a, 0x8b, 0xb9, 0x61, 0xdd, 0x53, 0x2c, 0x50, 0x90, 0x26, 0xbc, 0x0f, 0x20, 0x2b, 0xcc, 0xc8,
+	0xf7, 0x74, 0x91, 0x4b, 0x44, 0x8b, 0x3f, 0x81, 0x76, 0x56, 0xf1, 0x86, 0x73, 0x5f, 0x71, 0x5a,
+	0x19, 0xac, 0x88, 0xbd, 0x7f, 0x15, 0x00, 0x2d, 0x73, 0xf7, 0x54, 0x9f, 0x5d, 0x82, 0x9e, 0x41,
+	0x25, 0xe2, 0x6c, 0xc6, 0x42, 0xd3, 0x3c, 0x7f, 0x9e, 0xdf, 0xd4, 0x89, 0x92, 0x19, 0x36, 0x36,
+	0x54, 0xf4, 0x0a, 0x9a, 0x41, 0x14, 0xd0, 0x50, 0xb8, 0x32, 0x8b, 0x22, 0x6e, 0x5a, 0xd6, 0x87,
+	0x79, 0xdd, 0x91, 0xa2, 0x8c, 0x15, 0x23, 0x5d, 0xa1, 0x11, 0xac, 0x80, 0xe8, 0x18, 0xda, 0xd3,
+	0x68, 0x42, 0x7c, 0x37, 0xa0, 0x93, 0x39, 0x09, 0x59, 0x12, 0xa8, 0xba, 0xa8, 0xf7, 0x1f, 0xe6,
+	0x57, 0x7a, 0x25, 0x49, 0xa3, 0x94, 0x93, 0xae, 0xd5, 0x9a, 0xe6, 0x60, 0xf4, 0x25, 0x54, 0x93,
+	0x39, 0xb9, 0xa4, 0x01, 0x89, 0x55, 0x05, 0xd5, 0xfb, 0xef, 0xaf, 0xe5, 0xb2, 0x94, 0x8e, 0x48,
+	0x9c, 0x2e, 0x90, 0xd1, 0xd1, 0xe7, 0x50, 0x8e, 0xc9, 0x8c, 0x72, 0x55, 0x57, 0xf5, 0xfe, 0x83,
+	0xbc, 0xde, 0xa9, 0x14, 0xa5, 0x4a, 0x9a, 0x88, 0x1e, 0x43, 0xc9, 0xbb, 0x99, 0x32, 0xbb, 0xb2,
+	0xe9, 0xda, 0x18, 0xde, 0x4c, 0x59, 0xca, 0x57, 0xb4, 0x9e, 0x80, 0xba, 0x01, 0x8e, 0xc2, 0x69,
+	0x84, 0xf6, 0xa0, 0xa2, 0xb3, 0xdb, 0xb4, 0x0d, 0x33, 0xcb, 0x4a, 0xa5, 0xb0, 0x52, 0x2a, 0xeb,
+	0xcd, 0xb9, 0x78, 0xbb, 0x39, 0xef, 0x65, 0x35, 0x5c, 0x32, 0xcb, 0xa9, 0x59, 0xef, 0x9f, 0x45,
+	0x68, 0xe6, 0x22, 0x28, 0xdd, 0x66, 0xe1, 0x34, 0xb2, 0xad, 0x4d, 0x6e, 0xaf, 0x78, 0x88, 0x15,
+	0xed, 0x7f, 0xbc, 0x96, 0xd2, 0x2b, 0xa6, 0xb8, 0x72, 0xc5, 0xe4, 0xae, 0xaa, 0xd2, 0xfa, 0x55,
+	0xb5, 0x5a, 0x73, 0xe5, 0x7c, 0xcd, 0x6d, 0xaa, 0xa5, 0xca, 0xe6, 0x5a, 0xfa, 0x08, 0x5a, 0x57,
+	0x94, 0x0b, 0x36, 0xc9, 0x88, 0x5b, 0x8a, 0xd8, 0x4c, 0xd1, 0x77, 0x96, 0x4c, 0x75, 0x53, 0xc9,
+	0xa0, 0x47, 0xb0, 0x1d, 0x2e, 0x02, 0x57, 0x1e, 0x26, 0x8b, 0xc2, 0xc4, 0x5d, 0x24, 0xd4, 0x33,
+	0x97, 0x54, 0x3b, 0x5c, 0x04, 0x67, 0x06, 0x3f, 0x4f, 0xa8, 0x27, 0x9b, 0x14, 0x79, 0xcb, 0x82,
+	0x85, 0x98, 0x13, 0xdf, 0x95, 0x1d, 0x05, 0xd4, 0x92, 0x8d, 0x0c, 0x3c, 0x24, 0xb1, 0x74, 0x50,
+	0x5e, 0x3c, 0x1e, 0xe1, 0x9e, 0x31, 0x5c, 0xd7, 0x0e, 0xa6, 0xa8, 0xb6, 0xfb, 0x10, 0x9a, 0x9c,
+	0x5e, 0x31, 0xfa, 0xbd, 0x6b, 0xa2, 0xaa, 0x1b, 0x62, 0x43, 0x83, 0xba, 0x23, 0xf7, 0x7e, 0x07,
+	0xf0, 0x3a, 0xf2, 0x88, 0x7f, 0xea, 0x93, 0xd0, 0x64, 0x00, 0x67, 0x97, 0x3a, 0xa1, 0x0a, 0xd8,
+	0xcc, 0x64, 0x7b, 0xf3, 0x58, 0xac, 0x62, 0x57, 0xc0, 0x72, 0x28, 0x83, 0xc3, 0xc9, 0x65, 0xfa,
+	0x5c, 0x50, 0xe3, 0xde, 0x0f, 0x25, 0xd8, 0xd9, 0x50, 0xad, 0x77, 0xcd, 0x96, 0xcf, 0x60, 0xdb,
+	0xa3, 0x9c, 0x5d, 0x51, 0xcf, 0x5d, 0xc6, 0x5a, 0x9b, 0xee, 0x18, 0xc1, 0x28, 0x0b, 0xf9, 0x17,
+	0xb0, 0x77, 0x8b, 0xac, 0x13, 0x40, 0x5f, 0x8d, 0xbb, 0xeb, 0x1a, 0x2a, 0x1b, 0x3e, 0x82, 0x56,
+	0xaa, 0x35, 0xa7, 0x6c, 0x36, 0xd7, 0x77, 0xe5, 0x36, 0x6e, 0x1a, 0xf4, 0x2b, 0x05, 0xca, 0x13,
+	0x4c, 0x26, 0xc4, 0x27, 0xdc, 0xd5, 0xfd, 0x46, 0x25, 0x95, 0x85, 0x1b, 0x1a, 0xd4, 0x5b, 0x55,
+	0x9d, 0x55, 0x6d, 0xd7, 0x0d, 0xb8, 0xce, 0x29, 0x0b, 0xd7, 0x34, 0x32, 0xe2, 0x7c, 0x55, 0x2c,
+	0xf4, 0x63, 0x67, 0x29, 0x16, 0x39, 0xed, 0x38, 0xb6, 0xab, 0x39, 0x71, 0x1c, 0xe7, 0x16, 0x17,
+	0x76, 0x2d, 0x27, 0xe6, 0x79, 0xdb, 0x3a, 0x57, 0x56, 0xc4, 0x39, 0x6d, 0x11, 0xdb, 0xf5, 0x9c,
+	0x58, 0xc4, 0xa8, 0x0f, 0xf7, 0x62, 0xca, 0x27, 0xb2, 0x01, 0x7b, 0xd1, 0xe2, 0xc2, 0xa7, 0xee,
+	0x24, 0x5a, 0xc4, 0x3e, 0x55, 0x89, 0x52, 0xc0, 0x3b, 0x46, 0x38, 0x54, 0xb2, 0x97, 0x4a, 0x84,
+	0x7e, 0x0d, 0x8d, 0x50, 0xe6, 0x8b, 0x1b, 0xcb, 0x84, 0x39, 0xb0, 0x9b, 0x9b, 0xca, 0x79, 0x99,
+	0x51, 0xb8, 0x1e, 0x66, 0xe3, 0x83, 0x35, 0xe5, 0xbe, 0xdd, 0xfa, 0xef, 0x95, 0xfb, 0xbd, 0xbf,
+	0x5b, 0x70, 0x6f, 0x63, 0x07, 0xbf, 0x6b, 0x7e, 0xad, 0x6f, 0xa1, 0xf0, 0xff, 0x6c, 0xa1, 0x78,
+	0x97, 0x2d, 0xfc, 0xd9, 0x82, 0xf6, 0xda, 0xed, 0x71, 0x57, 0xe7, 0xef, 0xc3, 0x56, 0x40, 0xae,
+	0xdd, 0x20, 0x60, 0xa6, 0x24, 0x2a, 0x01, 0xb9, 0x1e, 0x05, 0x2c, 0x15, 0xc4, 0x33, 0x62, 0x17,
+	0x33, 0xc1, 0xe9, 0x8c, 0x2c, 0x05, 0x57, 0x76, 0x69, 0x45, 0x70, 0x25, 0xb3, 0x23, 0x20, 0x71,
+	0xda, 0x1c, 0x74, 0xbf, 0xac, 0x05, 0x24, 0x36, 0x9d, 0xe1, 0x4f, 0x16, 0x34, 0x56, 0xaf, 0xac,
+	0xbb, 0x7a, 0x9a, 0xbd, 0xe0, 0x0b, 0xff, 0xf1, 0x05, 0xbf, 0xb2, 0xa9, 0xe2, 0xea, 0xa6, 0x7a,
+	0x6f, 0xa1, 0xbe, 0x72, 0x09, 0xfe, 0x64, 0x67, 0xf5, 0x10, 0x9a, 0xb2, 0x23, 0x73, 0x9a, 0xc4,
+	0x51, 0x98, 0xa8, 0x0f, 0x30, 0xf5, 0xc9, 0x10, 0x2e, 0x02, 0x9c, 0x62, 0x3d, 0x0e, 0xed, 0xe5,
+	0x43, 0xc7, 0xb9, 0x92, 0xa5, 0xfe, 0x99, 0x79, 0xb8, 0x59, 0x6a, 0x47, 0xf7, 0xd7, 0x3e, 0x21,
+	0x24, 0x65, 0xe5, 0xf5, 0xd6, 0x87, 0x2d, 0xf3, 0xd9, 0xb6, 0x39, 0xc3, 0x96, 0x8b, 0xe3, 0x94,
+	0xd8, 0xfb, 0x8b, 0x05, 0xe8, 0xf6, 0x87, 0xa6, 0x6c, 0x57, 0x33, 0x1a, 0x52, 0xbe, 0xbc, 0xbd,
+	0x2d, 0x75, 0x27, 0x36, 0x33, 0x54, 0xdd, 0xdf, 0xb7, 0xbf, 0x18, 0x76, 0xa1, 0x2c, 0x98, 0xf0,
+	0xd3, 0x66, 0xa8, 0x27, 0x92, 0x47, 0x62, 0x66, 0x2e, 0x79, 0x39, 0x94, 0xbc, 0x49, 0xb4, 0x30,
+	0x0d, 0xae, 0x8c, 0xf5, 0x44, 0x3e, 0x2a, 0xe7, 0x42, 0x64, 0x19, 0x52, 0x51, 0x7c, 0x90, 0x90,
+	0x49, 0x91, 0x1f, 0x2c, 0xa8, 0xaf, 0x7c, 0xdb, 0xca, 0x37, 0x46, 0xc0, 0x42, 0xd7, 0x27, 0x42,
+	0x37, 0x6d, 0xe9, 0x65, 0x1b, 0xd7, 0x03, 0x16, 0x1e, 0x1b, 0x48, 0x1e, 0xbd, 0xa2, 0x44, 0xe1,
+	0x6c, 0xd9, 0xd8, 0xdb, 0x58, 0xea, 0x1d, 0xa7, 0x98, 0xca, 0x4c, 0x16, 0xa6, 0xad, 0xb9, 0xa8,
+	0x5a, 0x73, 0x2d, 0x60, 0xa1, 0x69, 0xcb, 0xd2, 0x0c, 0xb9, 0x5e, 0x9a, 0x29, 0x19, 0x33, 0xe4,
+	0x3a, 0x67, 0x46, 0x52, 0x32, 0x33, 0x65, 0x63, 0x86, 0x5c, 0xe7, 0xcd, 0x90, 0xeb, 0xd4, 0x4c,
+	0xc5, 0x98, 0x21, 0xd7, 0xda, 0x4c, 0xef, 0xb7, 0x50, 0x3b, 0xa4, 0x11, 0x66, 0xe1, 0xcc, 0x79,
+	0x8e, 0x3e, 0x87, 0x4a, 0x2c, 0x5f, 0x28, 0x89, 0x6d, 0x75, 0x8b, 0x3f, 0xfa, 0x80, 0x31, 0xbc,
+	0x1e, 0x87, 0x86, 0x42, 0xfd, 0x9b, 0x59, 0x14, 0x3a, 0xcf, 0xd1, 0x33, 0xa8, 0xd2, 0x6b, 0x41,
+	0x39, 0x8b, 0xb8, 0x49, 0xe0, 0xfb, 0xb7, 0xd6, 0xd0, 0xc6, 0x70, 0x46, 0x44, 0x8f, 0xa1, 0x3c,
+	0x8f, 0x7c, 0x2a, 0xff, 0x2b, 0x14, 0x7f, 0x4c, 0x43, 0xb3, 0x7a, 0x5f, 0xc3, 0xf6, 0x21, 0x8d,
+	0x46, 0x0b, 0x5f, 0xb0, 0xa5, 0xe1, 0x5f, 0xc9, 0xd7, 0x97, 0x9a, 0xa4, 0xce, 0x3f, 0xd8, 0xe0,
+	0xbc, 0x61, 0xe3, 0x8c, 0xdb, 0xfb, 0x03, 0xc0, 0x72, 0x5b, 0xe8, 0x01, 0x54, 0xd7, 0xe2, 0x9a,
+	0xcd, 0xe5, 0xab, 0x6c, 0x3d, 0xa0, 0x4b, 0x40, 0x3e, 0x2a, 0x72, 0x91, 0x34, 0xb3, 0x47, 0x17,
+	0x50, 0x56, 0x5d, 0x00, 0xdd, 0x83, 0xed, 0xc1, 0xb1, 0x83, 0xc7, 0xee, 0xf9, 0xeb, 0xb3, 0x53,
+	0xe7, 0xe5, 0xd1, 0xab, 0x23, 0x67, 0xd8, 0xf9, 0x19, 0x6a, 0x42, 0x4d, 0xc3, 0xd8, 0x19, 0x76,
+	0x2c, 0xd4, 0x81, 0x86, 0x9e, 0x9e, 0xe0, 0xc1, 0xeb, 0x43, 0xa7, 0x53, 0x58, 0x22, 0x6f, 0x9c,
+	0xe3, 0xe3, 0x93, 0xef, 0x3a, 0x45, 0xd4, 0x86, 0xba, 0x46, 0x0e, 0xb1, 0xe3, 0xbc, 0xee, 0x94,
+	0x1e, 0xb9, 0x50, 0xd1, 0xb9, 0x8a, 0xf6, 0x00, 0x9d, 0x8d, 0x07, 0xe3, 0xf3, 0xb3, 0x35, 0x2b,
+	0xbb, 0xd0, 0x31, 0xf8, 0xe0, 0x7c, 0x7c, 0x32, 0x1a, 0x8c, 0x8f, 0x5e, 0x76, 0x2c, 0xb4, 0x03,
+	0x6d, 0x83, 0x62, 0xe7, 0xdb, 0x23, 0xe7, 0x3b, 0x67, 0xd8, 0x29, 0x20, 0x04, 0x2d, 0x03, 0x0e,
+	0x9d, 0x63, 0x67, 0xec, 0x0c, 0x3b, 0xc5, 0x47, 0x2f, 0xa0, 0xa4, 0x5e, 0x14, 0xbb, 0xd0, 0x19,
+	0xbf, 0x39, 0x75, 0xd6, 0x16, 0xdf, 0x81, 0xb6, 0x42, 0x9d, 0x01, 0x1e, 0x7f, 0xf5, 0xcd, 0xf9,
+	0xe0, 0x6b, 0xa7, 0x63, 0x49, 0x27, 0x15, 0xf8, 0xcd, 0xf9, 0x00, 0xe3, 0x37, 0x9d, 0xc2, 0xa3,
+	0x00, 0x6a, 0x59, 0xf3, 0x40, 0x0f, 0x60, 0xcf, 0xf9, 0xd6, 0x79, 0x3d, 0x76, 0x37, 0x2c, 0xb7,
+	0x0b, 0x9d, 0x15, 0xd9, 0x60, 0x38, 0x54, 0x07, 0xb3, 0x07, 0x68, 0x55, 0xe3, 0x74, 0x38, 0x18,
+	0x2b, 0x77, 0xf3, 0x78, 0xe6, 0xf2, 0x8b, 0xd2, 0xef, 0x0b, 0x57, 0x07, 0x17, 0x15, 0xf5, 0x7b,
+	0xec, 0xd9, 0xbf, 0x07, 0x00, 0xeb, 0x47, 0xd1, 0x6c, 0x3a, 0x13, 0x00, 0x00,
 }
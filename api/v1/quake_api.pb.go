@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -97,6 +98,80 @@ func (Past) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_c0ffc9850e3e8dd8, []int{1}
 }
 
+// DistanceMetric is an enum for metrics of distances from a focus.
+type DistanceMetric int32
+
+const (
+	DistanceMetric_DISTANCE_METRIC_UNSPECIFIED DistanceMetric = 0
+	// A great-circle distance on the surface (ignoring depths).
+	DistanceMetric_DISTANCE_METRIC_SURFACE DistanceMetric = 1
+	// A straight line distance from a focus (with height) to a hypocenter.
+	DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL DistanceMetric = 2
+)
+
+var DistanceMetric_name = map[int32]string{
+	0: "DISTANCE_METRIC_UNSPECIFIED",
+	1: "DISTANCE_METRIC_SURFACE",
+	2: "DISTANCE_METRIC_HYPOCENTRAL",
+}
+
+var DistanceMetric_value = map[string]int32{
+	"DISTANCE_METRIC_UNSPECIFIED": 0,
+	"DISTANCE_METRIC_SURFACE":     1,
+	"DISTANCE_METRIC_HYPOCENTRAL": 2,
+}
+
+func (x DistanceMetric) String() string {
+	return proto.EnumName(DistanceMetric_name, int32(x))
+}
+
+func (DistanceMetric) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{2}
+}
+
+// SortKey is an enum for properties of earthquakes to sort by.
+type SortKey int32
+
+const (
+	SortKey_SORT_KEY_UNSPECIFIED  SortKey = 0
+	SortKey_SORT_KEY_TIME         SortKey = 1
+	SortKey_SORT_KEY_UPDATED_TIME SortKey = 2
+	SortKey_SORT_KEY_MAGNITUDE    SortKey = 3
+	SortKey_SORT_KEY_SIGNIFICANCE SortKey = 4
+	// An alert level from no alert, green, yellow and orange to red.
+	SortKey_SORT_KEY_ALERT SortKey = 5
+	// A distance from a focus (by the distance metric of a request).
+	SortKey_SORT_KEY_DISTANCE SortKey = 6
+)
+
+var SortKey_name = map[int32]string{
+	0: "SORT_KEY_UNSPECIFIED",
+	1: "SORT_KEY_TIME",
+	2: "SORT_KEY_UPDATED_TIME",
+	3: "SORT_KEY_MAGNITUDE",
+	4: "SORT_KEY_SIGNIFICANCE",
+	5: "SORT_KEY_ALERT",
+	6: "SORT_KEY_DISTANCE",
+}
+
+var SortKey_value = map[string]int32{
+	"SORT_KEY_UNSPECIFIED":  0,
+	"SORT_KEY_TIME":         1,
+	"SORT_KEY_UPDATED_TIME": 2,
+	"SORT_KEY_MAGNITUDE":    3,
+	"SORT_KEY_SIGNIFICANCE": 4,
+	"SORT_KEY_ALERT":        5,
+	"SORT_KEY_DISTANCE":     6,
+}
+
+func (x SortKey) String() string {
+	return proto.EnumName(SortKey_name, int32(x))
+}
+
+func (SortKey) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{3}
+}
+
 // ListEarthquakesRequest defines parameters for the ListEarthquakes method.
 type ListEarthquakesRequest struct {
 	// Magnitude sets the minimum magnitude for filtering earthquakes.
@@ -107,16 +182,50 @@ type ListEarthquakesRequest struct {
 	Limit uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	// Details, if true, tells to return earthquakes with detailed data.
 	Details bool `protobuf:"varint,4,opt,name=details,proto3" json:"details,omitempty"`
-	// Focus is spatial filter - either around a position or inside bounds.
-	// Note that only one ot these properties can be set for a request.
+	// Focus is spatial filter - either around a position, inside bounds or
+	// inside an area of polygons (earthquakes nearest to the center of its
+	// bounds coming first). Note that only one ot these properties can be
+	// set for a request.
 	//
 	// Types that are valid to be assigned to Focus:
 	//	*ListEarthquakesRequest_Position
 	//	*ListEarthquakesRequest_Bounds
-	Focus                isListEarthquakesRequest_Focus `protobuf_oneof:"focus"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+	//	*ListEarthquakesRequest_Area
+	Focus isListEarthquakesRequest_Focus `protobuf_oneof:"focus"`
+	// Window is an optional time window for earthquakes. When the window
+	// fits inside cached feeds (up to the past 30 days) earthquakes are
+	// filtered from those, otherwise earthquakes are queried from the USGS
+	// FDSN event web service. When set, the past filter is used only to
+	// resolve an open start of the window.
+	Window *TimeWindow `protobuf:"bytes,7,opt,name=window,proto3" json:"window,omitempty"`
+	// MagnitudeRange is an optional magnitude filter. When the minimum is
+	// set it's used instead of the magnitude filter.
+	MagnitudeRange *MagnitudeRange `protobuf:"bytes,8,opt,name=magnitude_range,json=magnitudeRange,proto3" json:"magnitude_range,omitempty"`
+	// PageSize is a maximum number of earthquakes to return on a page. When
+	// paging (page_size or page_token set) earthquakes are ordered by sort,
+	// or if not set by time (newest first) or by distance when focusing, and
+	// limit is not applied.
+	PageSize uint32 `protobuf:"varint,9,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// PageToken is a next_page_token from a previous response to get the
+	// next page. Other parameters must be same as on the previous request.
+	PageToken string `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// MaxDistanceMeters is an optional cutoff for the position focus, when
+	// greater than 0 only earthquakes within this distance (meters) from the
+	// position are returned. Not allowed without the position focus.
+	MaxDistanceMeters float64 `protobuf:"fixed64,11,opt,name=max_distance_meters,json=maxDistanceMeters,proto3" json:"max_distance_meters,omitempty"`
+	// DepthRange is an optional depth filter (kilometers below sea level).
+	DepthRange *DepthRange `protobuf:"bytes,13,opt,name=depth_range,json=depthRange,proto3" json:"depth_range,omitempty"`
+	// DistanceMetric is a metric for distances from a focus (ordering
+	// earthquakes, the max distance cutoff and distances on results). If
+	// unspecified, surface distances are used. Not allowed without a focus.
+	DistanceMetric DistanceMetric `protobuf:"varint,14,opt,name=distance_metric,json=distanceMetric,proto3,enum=quake.api.v1.DistanceMetric" json:"distance_metric,omitempty"`
+	// Sort is an optional order for earthquakes (applied before limit). If
+	// not set earthquakes are ordered as on USGS feeds, or by distance when
+	// focusing.
+	Sort                 *Sort    `protobuf:"bytes,15,opt,name=sort,proto3" json:"sort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListEarthquakesRequest) Reset()         { *m = ListEarthquakesRequest{} }
@@ -184,10 +293,16 @@ type ListEarthquakesRequest_Bounds struct {
 	Bounds *GeoBoundsE7 `protobuf:"bytes,6,opt,name=bounds,proto3,oneof"`
 }
 
+type ListEarthquakesRequest_Area struct {
+	Area *GeoMultiPolygonE7 `protobuf:"bytes,12,opt,name=area,proto3,oneof"`
+}
+
 func (*ListEarthquakesRequest_Position) isListEarthquakesRequest_Focus() {}
 
 func (*ListEarthquakesRequest_Bounds) isListEarthquakesRequest_Focus() {}
 
+func (*ListEarthquakesRequest_Area) isListEarthquakesRequest_Focus() {}
+
 func (m *ListEarthquakesRequest) GetFocus() isListEarthquakesRequest_Focus {
 	if m != nil {
 		return m.Focus
@@ -209,28 +324,299 @@ func (m *ListEarthquakesRequest) GetBounds() *GeoBoundsE7 {
 	return nil
 }
 
+func (m *ListEarthquakesRequest) GetArea() *GeoMultiPolygonE7 {
+	if x, ok := m.GetFocus().(*ListEarthquakesRequest_Area); ok {
+		return x.Area
+	}
+	return nil
+}
+
+func (m *ListEarthquakesRequest) GetWindow() *TimeWindow {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+func (m *ListEarthquakesRequest) GetMagnitudeRange() *MagnitudeRange {
+	if m != nil {
+		return m.MagnitudeRange
+	}
+	return nil
+}
+
+func (m *ListEarthquakesRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListEarthquakesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListEarthquakesRequest) GetMaxDistanceMeters() float64 {
+	if m != nil {
+		return m.MaxDistanceMeters
+	}
+	return 0
+}
+
+func (m *ListEarthquakesRequest) GetDepthRange() *DepthRange {
+	if m != nil {
+		return m.DepthRange
+	}
+	return nil
+}
+
+func (m *ListEarthquakesRequest) GetDistanceMetric() DistanceMetric {
+	if m != nil {
+		return m.DistanceMetric
+	}
+	return DistanceMetric_DISTANCE_METRIC_UNSPECIFIED
+}
+
+func (m *ListEarthquakesRequest) GetSort() *Sort {
+	if m != nil {
+		return m.Sort
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ListEarthquakesRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*ListEarthquakesRequest_Position)(nil),
 		(*ListEarthquakesRequest_Bounds)(nil),
+		(*ListEarthquakesRequest_Area)(nil),
+	}
+}
+
+// TimeWindow is a time window with time as UTC time (seconds) since Unix
+// epoch 1970-01-01T00:00:00Z.
+type TimeWindow struct {
+	// Start time (inclusive). If 0 the window starts at the start of the
+	// past period (or 30 days ago if the past is unspecified).
+	StartTime int64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time (inclusive). If 0 the window is open ended.
+	EndTime              int64    `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TimeWindow) Reset()         { *m = TimeWindow{} }
+func (m *TimeWindow) String() string { return proto.CompactTextString(m) }
+func (*TimeWindow) ProtoMessage()    {}
+func (*TimeWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{1}
+}
+
+func (m *TimeWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeWindow.Unmarshal(m, b)
+}
+func (m *TimeWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeWindow.Marshal(b, m, deterministic)
+}
+func (m *TimeWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeWindow.Merge(m, src)
+}
+func (m *TimeWindow) XXX_Size() int {
+	return xxx_messageInfo_TimeWindow.Size(m)
+}
+func (m *TimeWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeWindow proto.InternalMessageInfo
+
+func (m *TimeWindow) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *TimeWindow) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+// MagnitudeRange is a range for earthquake magnitudes.
+type MagnitudeRange struct {
+	// Minimum magnitude (inclusive), if not set then no minimum apply.
+	Min *wrappers.FloatValue `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	// Maximum magnitude (inclusive), if not set then no maximum apply.
+	Max                  *wrappers.FloatValue `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MagnitudeRange) Reset()         { *m = MagnitudeRange{} }
+func (m *MagnitudeRange) String() string { return proto.CompactTextString(m) }
+func (*MagnitudeRange) ProtoMessage()    {}
+func (*MagnitudeRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{2}
+}
+
+func (m *MagnitudeRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MagnitudeRange.Unmarshal(m, b)
+}
+func (m *MagnitudeRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MagnitudeRange.Marshal(b, m, deterministic)
+}
+func (m *MagnitudeRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MagnitudeRange.Merge(m, src)
+}
+func (m *MagnitudeRange) XXX_Size() int {
+	return xxx_messageInfo_MagnitudeRange.Size(m)
+}
+func (m *MagnitudeRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_MagnitudeRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MagnitudeRange proto.InternalMessageInfo
+
+func (m *MagnitudeRange) GetMin() *wrappers.FloatValue {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *MagnitudeRange) GetMax() *wrappers.FloatValue {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+// DepthRange is a range for earthquake depths (kilometers below sea level,
+// negative values meaning above sea level).
+type DepthRange struct {
+	// Minimum depth (inclusive), if not set then no minimum apply.
+	Min *wrappers.FloatValue `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	// Maximum depth (inclusive), if not set then no maximum apply.
+	Max                  *wrappers.FloatValue `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DepthRange) Reset()         { *m = DepthRange{} }
+func (m *DepthRange) String() string { return proto.CompactTextString(m) }
+func (*DepthRange) ProtoMessage()    {}
+func (*DepthRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{3}
+}
+
+func (m *DepthRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepthRange.Unmarshal(m, b)
+}
+func (m *DepthRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepthRange.Marshal(b, m, deterministic)
+}
+func (m *DepthRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepthRange.Merge(m, src)
+}
+func (m *DepthRange) XXX_Size() int {
+	return xxx_messageInfo_DepthRange.Size(m)
+}
+func (m *DepthRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepthRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepthRange proto.InternalMessageInfo
+
+func (m *DepthRange) GetMin() *wrappers.FloatValue {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *DepthRange) GetMax() *wrappers.FloatValue {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+// Sort is an order for earthquakes by a sort key, earthquakes with equal
+// values are ordered by id.
+type Sort struct {
+	// Key is a property of earthquakes to sort by.
+	Key SortKey `protobuf:"varint,1,opt,name=key,proto3,enum=quake.api.v1.SortKey" json:"key,omitempty"`
+	// Descending, if true, tells to order by the largest values first.
+	Descending           bool     `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Sort) Reset()         { *m = Sort{} }
+func (m *Sort) String() string { return proto.CompactTextString(m) }
+func (*Sort) ProtoMessage()    {}
+func (*Sort) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{4}
+}
+
+func (m *Sort) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sort.Unmarshal(m, b)
+}
+func (m *Sort) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sort.Marshal(b, m, deterministic)
+}
+func (m *Sort) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sort.Merge(m, src)
+}
+func (m *Sort) XXX_Size() int {
+	return xxx_messageInfo_Sort.Size(m)
+}
+func (m *Sort) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sort.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sort proto.InternalMessageInfo
+
+func (m *Sort) GetKey() SortKey {
+	if m != nil {
+		return m.Key
 	}
+	return SortKey_SORT_KEY_UNSPECIFIED
+}
+
+func (m *Sort) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
 }
 
 // ListEarthquakesResponse defines the response for the ListEarthquakes method.
 type ListEarthquakesResponse struct {
 	// EarthquakeCollection with earthquakes.
-	Collection           *EarthquakeCollection `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Collection *EarthquakeCollection `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// NextPageToken is a token to get the next page when paging, or empty if
+	// there are no more earthquakes.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListEarthquakesResponse) Reset()         { *m = ListEarthquakesResponse{} }
 func (m *ListEarthquakesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListEarthquakesResponse) ProtoMessage()    {}
 func (*ListEarthquakesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0ffc9850e3e8dd8, []int{1}
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{5}
 }
 
 func (m *ListEarthquakesResponse) XXX_Unmarshal(b []byte) error {
@@ -258,12 +644,21 @@ func (m *ListEarthquakesResponse) GetCollection() *EarthquakeCollection {
 	return nil
 }
 
+func (m *ListEarthquakesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 // GetEarthquakeRequest defines parameters for the GetEarthquake method.
 type GetEarthquakeRequest struct {
 	// ID of an earthquake to be searched.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// Details, if true, tells to return an earthquake with detailed data.
-	Details              bool     `protobuf:"varint,2,opt,name=details,proto3" json:"details,omitempty"`
+	Details bool `protobuf:"varint,2,opt,name=details,proto3" json:"details,omitempty"`
+	// Products, if true, tells to return also products from the detail feed.
+	Products             bool     `protobuf:"varint,3,opt,name=products,proto3" json:"products,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -273,7 +668,7 @@ func (m *GetEarthquakeRequest) Reset()         { *m = GetEarthquakeRequest{} }
 func (m *GetEarthquakeRequest) String() string { return proto.CompactTextString(m) }
 func (*GetEarthquakeRequest) ProtoMessage()    {}
 func (*GetEarthquakeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0ffc9850e3e8dd8, []int{2}
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{6}
 }
 
 func (m *GetEarthquakeRequest) XXX_Unmarshal(b []byte) error {
@@ -308,20 +703,32 @@ func (m *GetEarthquakeRequest) GetDetails() bool {
 	return false
 }
 
+func (m *GetEarthquakeRequest) GetProducts() bool {
+	if m != nil {
+		return m.Products
+	}
+	return false
+}
+
 // GetEarthquakeRequest defines the response for the GetEarthquake method.
 type GetEarthquakeResponse struct {
 	// Feature as an Earthquake.
-	Feature              *Earthquake `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Feature *Earthquake `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	// Superseded is true if the earthquake was found by an id that is not
+	// its current preferred id but one of the ids associated to it.
+	Superseded bool `protobuf:"varint,2,opt,name=superseded,proto3" json:"superseded,omitempty"`
+	// Products of the earthquake (only if asked, can be null).
+	Products             *EarthquakeProducts `protobuf:"bytes,3,opt,name=products,proto3" json:"products,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *GetEarthquakeResponse) Reset()         { *m = GetEarthquakeResponse{} }
 func (m *GetEarthquakeResponse) String() string { return proto.CompactTextString(m) }
 func (*GetEarthquakeResponse) ProtoMessage()    {}
 func (*GetEarthquakeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0ffc9850e3e8dd8, []int{3}
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{7}
 }
 
 func (m *GetEarthquakeResponse) XXX_Unmarshal(b []byte) error {
@@ -349,52 +756,267 @@ func (m *GetEarthquakeResponse) GetFeature() *Earthquake {
 	return nil
 }
 
+func (m *GetEarthquakeResponse) GetSuperseded() bool {
+	if m != nil {
+		return m.Superseded
+	}
+	return false
+}
+
+func (m *GetEarthquakeResponse) GetProducts() *EarthquakeProducts {
+	if m != nil {
+		return m.Products
+	}
+	return nil
+}
+
+// WatchEarthquakesRequest defines parameters for the WatchEarthquakes method.
+type WatchEarthquakesRequest struct {
+	// Magnitude sets the minimum magnitude for filtering earthquakes.
+	Magnitude Magnitude `protobuf:"varint,1,opt,name=magnitude,proto3,enum=quake.api.v1.Magnitude" json:"magnitude,omitempty"`
+	// Past is a period (like past day) filter.
+	Past Past `protobuf:"varint,2,opt,name=past,proto3,enum=quake.api.v1.Past" json:"past,omitempty"`
+	// Details, if true, tells to return earthquakes with detailed data.
+	Details bool `protobuf:"varint,3,opt,name=details,proto3" json:"details,omitempty"`
+	// Focus is spatial filter - either around a position or inside bounds.
+	// Note that only one ot these properties can be set for a request.
+	//
+	// Types that are valid to be assigned to Focus:
+	//	*WatchEarthquakesRequest_Position
+	//	*WatchEarthquakesRequest_Bounds
+	Focus                isWatchEarthquakesRequest_Focus `protobuf_oneof:"focus"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *WatchEarthquakesRequest) Reset()         { *m = WatchEarthquakesRequest{} }
+func (m *WatchEarthquakesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEarthquakesRequest) ProtoMessage()    {}
+func (*WatchEarthquakesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{8}
+}
+
+func (m *WatchEarthquakesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEarthquakesRequest.Unmarshal(m, b)
+}
+func (m *WatchEarthquakesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEarthquakesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchEarthquakesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEarthquakesRequest.Merge(m, src)
+}
+func (m *WatchEarthquakesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEarthquakesRequest.Size(m)
+}
+func (m *WatchEarthquakesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEarthquakesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEarthquakesRequest proto.InternalMessageInfo
+
+func (m *WatchEarthquakesRequest) GetMagnitude() Magnitude {
+	if m != nil {
+		return m.Magnitude
+	}
+	return Magnitude_MAGNITUDE_UNSPECIFIED
+}
+
+func (m *WatchEarthquakesRequest) GetPast() Past {
+	if m != nil {
+		return m.Past
+	}
+	return Past_PAST_UNSPECIFIED
+}
+
+func (m *WatchEarthquakesRequest) GetDetails() bool {
+	if m != nil {
+		return m.Details
+	}
+	return false
+}
+
+type isWatchEarthquakesRequest_Focus interface {
+	isWatchEarthquakesRequest_Focus()
+}
+
+type WatchEarthquakesRequest_Position struct {
+	Position *GeoPointE7 `protobuf:"bytes,4,opt,name=position,proto3,oneof"`
+}
+
+type WatchEarthquakesRequest_Bounds struct {
+	Bounds *GeoBoundsE7 `protobuf:"bytes,5,opt,name=bounds,proto3,oneof"`
+}
+
+func (*WatchEarthquakesRequest_Position) isWatchEarthquakesRequest_Focus() {}
+
+func (*WatchEarthquakesRequest_Bounds) isWatchEarthquakesRequest_Focus() {}
+
+func (m *WatchEarthquakesRequest) GetFocus() isWatchEarthquakesRequest_Focus {
+	if m != nil {
+		return m.Focus
+	}
+	return nil
+}
+
+func (m *WatchEarthquakesRequest) GetPosition() *GeoPointE7 {
+	if x, ok := m.GetFocus().(*WatchEarthquakesRequest_Position); ok {
+		return x.Position
+	}
+	return nil
+}
+
+func (m *WatchEarthquakesRequest) GetBounds() *GeoBoundsE7 {
+	if x, ok := m.GetFocus().(*WatchEarthquakesRequest_Bounds); ok {
+		return x.Bounds
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*WatchEarthquakesRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*WatchEarthquakesRequest_Position)(nil),
+		(*WatchEarthquakesRequest_Bounds)(nil),
+	}
+}
+
+// WatchEarthquakesResponse defines a streamed response for the
+// WatchEarthquakes method. The first response contains earthquakes currently
+// available as added events.
+type WatchEarthquakesResponse struct {
+	// Events occurred since the previous response.
+	Events               []*EarthquakeEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *WatchEarthquakesResponse) Reset()         { *m = WatchEarthquakesResponse{} }
+func (m *WatchEarthquakesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchEarthquakesResponse) ProtoMessage()    {}
+func (*WatchEarthquakesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0ffc9850e3e8dd8, []int{9}
+}
+
+func (m *WatchEarthquakesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEarthquakesResponse.Unmarshal(m, b)
+}
+func (m *WatchEarthquakesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEarthquakesResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchEarthquakesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEarthquakesResponse.Merge(m, src)
+}
+func (m *WatchEarthquakesResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchEarthquakesResponse.Size(m)
+}
+func (m *WatchEarthquakesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEarthquakesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEarthquakesResponse proto.InternalMessageInfo
+
+func (m *WatchEarthquakesResponse) GetEvents() []*EarthquakeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("quake.api.v1.Magnitude", Magnitude_name, Magnitude_value)
 	proto.RegisterEnum("quake.api.v1.Past", Past_name, Past_value)
+	proto.RegisterEnum("quake.api.v1.DistanceMetric", DistanceMetric_name, DistanceMetric_value)
+	proto.RegisterEnum("quake.api.v1.SortKey", SortKey_name, SortKey_value)
 	proto.RegisterType((*ListEarthquakesRequest)(nil), "quake.api.v1.ListEarthquakesRequest")
+	proto.RegisterType((*TimeWindow)(nil), "quake.api.v1.TimeWindow")
+	proto.RegisterType((*MagnitudeRange)(nil), "quake.api.v1.MagnitudeRange")
+	proto.RegisterType((*DepthRange)(nil), "quake.api.v1.DepthRange")
+	proto.RegisterType((*Sort)(nil), "quake.api.v1.Sort")
 	proto.RegisterType((*ListEarthquakesResponse)(nil), "quake.api.v1.ListEarthquakesResponse")
 	proto.RegisterType((*GetEarthquakeRequest)(nil), "quake.api.v1.GetEarthquakeRequest")
 	proto.RegisterType((*GetEarthquakeResponse)(nil), "quake.api.v1.GetEarthquakeResponse")
+	proto.RegisterType((*WatchEarthquakesRequest)(nil), "quake.api.v1.WatchEarthquakesRequest")
+	proto.RegisterType((*WatchEarthquakesResponse)(nil), "quake.api.v1.WatchEarthquakesResponse")
 }
 
 func init() { proto.RegisterFile("quake/api/v1/quake_api.proto", fileDescriptor_c0ffc9850e3e8dd8) }
 
 var fileDescriptor_c0ffc9850e3e8dd8 = []byte{
-	// 521 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xd9, 0x6e, 0xda, 0x40,
-	0x14, 0x8d, 0x1d, 0xb3, 0xdd, 0x00, 0x71, 0x47, 0x59, 0x1c, 0xd4, 0x07, 0xe4, 0x2e, 0x42, 0x79,
-	0x80, 0x00, 0x4d, 0xf3, 0x5a, 0xb3, 0x84, 0x58, 0x05, 0xea, 0x0e, 0x20, 0x35, 0x91, 0x5a, 0xe4,
-	0xc0, 0xa4, 0x1d, 0x95, 0x30, 0x0e, 0x33, 0xe6, 0x6b, 0xda, 0xff, 0xe9, 0x67, 0x55, 0x0c, 0x66,
-	0xb1, 0xe9, 0xf2, 0x78, 0xcf, 0xe6, 0xab, 0x73, 0xc7, 0xf0, 0xfc, 0xc9, 0x77, 0xbf, 0x93, 0x92,
-	0xeb, 0xd1, 0xd2, 0xbc, 0x5c, 0x92, 0xc3, 0xd0, 0xf5, 0x68, 0xd1, 0x9b, 0x31, 0xc1, 0x50, 0x5a,
-	0x02, 0xc5, 0x05, 0x30, 0x2f, 0xe7, 0x8c, 0x5d, 0xed, 0x52, 0x67, 0xfe, 0x54, 0xe1, 0xa4, 0x4d,
-	0xb9, 0x68, 0xba, 0x33, 0xf1, 0x4d, 0x12, 0x1c, 0x93, 0x27, 0x9f, 0x70, 0x81, 0x2e, 0x21, 0xf5,
-	0xe8, 0x7e, 0x9d, 0x52, 0xe1, 0x8f, 0x89, 0xa1, 0xe4, 0x95, 0x42, 0xb6, 0x72, 0x5a, 0xdc, 0x8e,
-	0x2d, 0x76, 0x56, 0x34, 0xde, 0x28, 0xd1, 0x6b, 0xd0, 0x3c, 0x97, 0x0b, 0x43, 0x95, 0x0e, 0x14,
-	0x76, 0x38, 0x2e, 0x17, 0x58, 0xf2, 0xe8, 0x08, 0x62, 0x13, 0xfa, 0x48, 0x85, 0xb1, 0x9f, 0x57,
-	0x0a, 0x1a, 0x5e, 0x0e, 0xc8, 0x80, 0xc4, 0x98, 0x08, 0x97, 0x4e, 0xb8, 0xa1, 0xe5, 0x95, 0x42,
-	0x12, 0xaf, 0x46, 0xf4, 0x16, 0x92, 0x1e, 0xe3, 0x54, 0x50, 0x36, 0x35, 0x62, 0x79, 0xa5, 0x70,
-	0x50, 0x31, 0xc2, 0xd9, 0x2d, 0xc2, 0x1c, 0x46, 0xa7, 0xa2, 0x79, 0x75, 0xb3, 0x87, 0xd7, 0x5a,
-	0x54, 0x85, 0xf8, 0x3d, 0xf3, 0xa7, 0x63, 0x6e, 0xc4, 0xa5, 0xeb, 0x6c, 0xc7, 0x55, 0x93, 0xb4,
-	0xb4, 0x05, 0xd2, 0x5a, 0x02, 0x62, 0x0f, 0x6c, 0xe4, 0x73, 0xf3, 0x33, 0x9c, 0xee, 0xd4, 0xc3,
-	0x3d, 0x36, 0xe5, 0x04, 0xd5, 0x00, 0x46, 0x6c, 0x32, 0x21, 0x23, 0xb9, 0x92, 0x22, 0xc3, 0xcd,
-	0x70, 0xf8, 0xc6, 0x56, 0x5f, 0x2b, 0xf1, 0x96, 0xcb, 0x7c, 0x07, 0x47, 0x2d, 0xb2, 0x95, 0xbe,
-	0xea, 0x3e, 0x0b, 0x2a, 0x1d, 0xcb, 0xcc, 0x14, 0x56, 0xe9, 0x78, 0xbb, 0x16, 0x35, 0x54, 0x8b,
-	0xf9, 0x1e, 0x8e, 0x23, 0x09, 0xc1, 0x7a, 0x15, 0x48, 0x3c, 0x10, 0x57, 0xf8, 0x33, 0x12, 0xec,
-	0x66, 0xfc, 0x6d, 0x37, 0xbc, 0x12, 0x9e, 0xff, 0x50, 0x20, 0xb5, 0x3e, 0x2a, 0x3a, 0x83, 0xe3,
-	0x8e, 0xd5, 0xea, 0xda, 0xfd, 0x41, 0xa3, 0x39, 0x1c, 0x74, 0x7b, 0x4e, 0xb3, 0x6e, 0x5f, 0xdb,
-	0xcd, 0x86, 0xbe, 0x17, 0xa6, 0x7a, 0x76, 0xab, 0x6b, 0x5f, 0xdb, 0x75, 0xab, 0xdb, 0xd7, 0x15,
-	0x74, 0x02, 0x68, 0x43, 0x75, 0xde, 0x5c, 0x0e, 0x9d, 0xf6, 0xa0, 0xa7, 0xab, 0x11, 0xbc, 0x12,
-	0xe0, 0xfb, 0x11, 0xbc, 0x7c, 0xb1, 0xc4, 0x35, 0xf4, 0x0c, 0x32, 0x1b, 0xdc, 0x6a, 0xb7, 0xf5,
-	0xd8, 0xf9, 0x1d, 0x68, 0xce, 0xf2, 0xe9, 0xe8, 0x8e, 0xd5, 0xeb, 0x47, 0x76, 0xca, 0x40, 0x4a,
-	0xa2, 0x37, 0x1f, 0x06, 0x58, 0x57, 0x50, 0x1a, 0x92, 0x72, 0x6c, 0x58, 0xb7, 0xba, 0x8a, 0xb2,
-	0x00, 0x72, 0xba, 0x6a, 0x58, 0xb7, 0x8b, 0xaf, 0x1e, 0xc2, 0x81, 0x9c, 0xab, 0x17, 0x12, 0xd0,
-	0x2a, 0xbf, 0x14, 0x48, 0x7f, 0x5c, 0xb4, 0xd1, 0x23, 0xb3, 0x39, 0x1d, 0x11, 0xf4, 0x05, 0x0e,
-	0x23, 0x97, 0x47, 0x2f, 0xc3, 0x0d, 0xfe, 0xf9, 0xbf, 0xc9, 0xbd, 0xfa, 0x8f, 0x2a, 0xb8, 0xcf,
-	0x27, 0xc8, 0x84, 0x0e, 0x87, 0xcc, 0xe8, 0xc3, 0xdc, 0x7d, 0x17, 0xb9, 0x17, 0xff, 0xd4, 0x2c,
-	0x93, 0x6b, 0xda, 0x9d, 0x3a, 0x2f, 0xdf, 0xc7, 0xe5, 0x0f, 0x5e, 0xfd, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x72, 0xe7, 0x34, 0x38, 0x28, 0x04, 0x00, 0x00,
+	// 1140 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xed, 0x4e, 0xe3, 0x46,
+	0x17, 0x5e, 0x3b, 0xdf, 0x27, 0x24, 0x98, 0x79, 0x61, 0x31, 0x61, 0x3f, 0xa2, 0xbc, 0x2d, 0x8d,
+	0x90, 0x1a, 0x20, 0x94, 0xae, 0x2a, 0xf5, 0x4f, 0x48, 0x0c, 0x44, 0x9b, 0x84, 0xec, 0xc4, 0x74,
+	0xcb, 0xaa, 0x6a, 0x34, 0xc4, 0x43, 0x98, 0x92, 0xd8, 0x59, 0x7b, 0xc2, 0xc7, 0xfe, 0xaf, 0xd4,
+	0x8b, 0xe8, 0x0d, 0xf4, 0x47, 0xd5, 0x8b, 0xea, 0x8d, 0x54, 0x1e, 0x3b, 0x1f, 0x76, 0xa0, 0xbb,
+	0xaa, 0xd4, 0xfe, 0xe3, 0x3c, 0xcf, 0x73, 0xe6, 0x9c, 0x39, 0x3e, 0xf3, 0x04, 0x78, 0xf6, 0x7e,
+	0x4c, 0xae, 0xe9, 0x0e, 0x19, 0xb1, 0x9d, 0x9b, 0xbd, 0x1d, 0x11, 0x74, 0xc9, 0x88, 0x95, 0x46,
+	0xb6, 0xc5, 0x2d, 0xb4, 0x24, 0x80, 0x92, 0x0b, 0xdc, 0xec, 0xe5, 0x5e, 0xf4, 0x2d, 0xab, 0x3f,
+	0xa0, 0x3b, 0x82, 0xbb, 0x18, 0x5f, 0xee, 0xdc, 0xda, 0x64, 0x34, 0xa2, 0xb6, 0xe3, 0xa9, 0x73,
+	0xea, 0xe2, 0x59, 0x1e, 0x53, 0xf8, 0x33, 0x06, 0x4f, 0x1b, 0xcc, 0xe1, 0x1a, 0xb1, 0xf9, 0x95,
+	0x20, 0x1c, 0x4c, 0xdf, 0x8f, 0xa9, 0xc3, 0xd1, 0x01, 0xa4, 0x86, 0xa4, 0x6f, 0x32, 0x3e, 0x36,
+	0xa8, 0x2a, 0xe5, 0xa5, 0x62, 0xb6, 0xbc, 0x5e, 0x9a, 0x2f, 0x5b, 0x6a, 0x4e, 0x68, 0x3c, 0x53,
+	0xa2, 0x2d, 0x88, 0x8e, 0x88, 0xc3, 0x55, 0x59, 0x64, 0xa0, 0x60, 0x46, 0x9b, 0x38, 0x1c, 0x0b,
+	0x1e, 0xad, 0x42, 0x6c, 0xc0, 0x86, 0x8c, 0xab, 0x91, 0xbc, 0x54, 0x8c, 0x62, 0x2f, 0x40, 0x2a,
+	0x24, 0x0c, 0xca, 0x09, 0x1b, 0x38, 0x6a, 0x34, 0x2f, 0x15, 0x93, 0x78, 0x12, 0xa2, 0xaf, 0x21,
+	0x39, 0xb2, 0x1c, 0xc6, 0x99, 0x65, 0xaa, 0xb1, 0xbc, 0x54, 0x4c, 0x97, 0xd5, 0xe0, 0xd9, 0xc7,
+	0xd4, 0x6a, 0x5b, 0xcc, 0xe4, 0xda, 0xab, 0x93, 0x27, 0x78, 0xaa, 0x45, 0xfb, 0x10, 0xbf, 0xb0,
+	0xc6, 0xa6, 0xe1, 0xa8, 0x71, 0x91, 0xb5, 0xb1, 0x90, 0x75, 0x28, 0x68, 0x91, 0xe6, 0x4b, 0xd1,
+	0x01, 0x44, 0x89, 0x4d, 0x89, 0xba, 0x24, 0x52, 0x5e, 0x2e, 0xa4, 0x34, 0xc7, 0x03, 0xce, 0xda,
+	0xd6, 0xe0, 0xbe, 0x6f, 0x99, 0x22, 0x51, 0xc8, 0xd1, 0x2e, 0xc4, 0x6f, 0x99, 0x69, 0x58, 0xb7,
+	0x6a, 0xe2, 0xa1, 0x0e, 0x75, 0x36, 0xa4, 0x6f, 0x05, 0x8f, 0x7d, 0x1d, 0xd2, 0x60, 0x79, 0x3a,
+	0xba, 0xae, 0x4d, 0xcc, 0x3e, 0x55, 0x93, 0x22, 0xf5, 0xd9, 0x63, 0xa3, 0x76, 0x35, 0x38, 0x3b,
+	0x0c, 0xc4, 0x68, 0x13, 0x52, 0x23, 0xd2, 0xa7, 0x5d, 0x87, 0x7d, 0xa0, 0x6a, 0x2a, 0x2f, 0x15,
+	0x33, 0x38, 0xe9, 0x02, 0x1d, 0xf6, 0x81, 0xa2, 0xe7, 0x00, 0x82, 0xe4, 0xd6, 0x35, 0x35, 0x55,
+	0xc8, 0x4b, 0xc5, 0x14, 0x16, 0x72, 0xdd, 0x05, 0x50, 0x09, 0xfe, 0x37, 0x24, 0x77, 0x5d, 0x83,
+	0x39, 0x9c, 0x98, 0x3d, 0xda, 0x1d, 0x52, 0x4e, 0x6d, 0x47, 0x4d, 0xe7, 0xa5, 0xa2, 0x84, 0x57,
+	0x86, 0xe4, 0xae, 0xe6, 0x33, 0x4d, 0x41, 0xa0, 0x6f, 0x20, 0x6d, 0xd0, 0x11, 0xbf, 0xf2, 0xdb,
+	0xcd, 0x3c, 0x74, 0xd3, 0x9a, 0x2b, 0xf0, 0x5a, 0x05, 0x63, 0xfa, 0xb7, 0x7b, 0xdb, 0xf9, 0x32,
+	0x36, 0xeb, 0xa9, 0x59, 0xb1, 0x26, 0xa1, 0xdb, 0xce, 0x55, 0xb4, 0x59, 0x0f, 0x67, 0x8d, 0x40,
+	0xec, 0xae, 0x98, 0x63, 0xd9, 0x5c, 0x5d, 0x16, 0xa5, 0x43, 0x2b, 0xd6, 0xb1, 0x6c, 0x8e, 0x05,
+	0x7f, 0x98, 0x80, 0xd8, 0xa5, 0xd5, 0x1b, 0x3b, 0x85, 0x23, 0x80, 0xd9, 0xec, 0xdd, 0x79, 0x38,
+	0x9c, 0xd8, 0xbc, 0xcb, 0xd9, 0xd0, 0xdb, 0xec, 0x08, 0x4e, 0x09, 0xc4, 0x15, 0xa1, 0x0d, 0x48,
+	0x52, 0xd3, 0xf0, 0x48, 0x59, 0x90, 0x09, 0x6a, 0x1a, 0x2e, 0x55, 0x30, 0x21, 0x1b, 0xfc, 0x10,
+	0xe8, 0x4b, 0x88, 0x0c, 0x99, 0x29, 0x0e, 0x49, 0x97, 0x37, 0x4b, 0xde, 0x3b, 0x2c, 0x4d, 0xde,
+	0x61, 0xe9, 0x68, 0x60, 0x11, 0xfe, 0x1d, 0x19, 0x8c, 0x29, 0x76, 0x75, 0x42, 0x4e, 0xee, 0x54,
+	0xf9, 0x53, 0xe4, 0xe4, 0xae, 0xf0, 0x13, 0xc0, 0x6c, 0x92, 0xff, 0x72, 0xad, 0x53, 0x88, 0xba,
+	0xa3, 0x43, 0x5f, 0x40, 0xe4, 0x9a, 0xde, 0xfb, 0x0f, 0x7e, 0x6d, 0x71, 0xb6, 0xaf, 0xe9, 0x3d,
+	0x76, 0x15, 0xe8, 0x05, 0x80, 0x41, 0x9d, 0x1e, 0x35, 0x0d, 0x66, 0xf6, 0x45, 0x99, 0x24, 0x9e,
+	0x43, 0x0a, 0x3f, 0x4b, 0xb0, 0xbe, 0x60, 0x2d, 0xce, 0xc8, 0x32, 0x1d, 0x8a, 0x0e, 0x01, 0x7a,
+	0xd6, 0x60, 0x40, 0x7b, 0xe2, 0x39, 0x7b, 0x37, 0x2a, 0x04, 0x6b, 0xcd, 0xd2, 0xaa, 0x53, 0x25,
+	0x9e, 0xcb, 0x42, 0x5b, 0xb0, 0x6c, 0xd2, 0x3b, 0xde, 0x9d, 0xdb, 0x6d, 0x59, 0xec, 0x76, 0xc6,
+	0x85, 0xdb, 0x93, 0xfd, 0x2e, 0xfc, 0x00, 0xab, 0xc7, 0x74, 0xae, 0x8b, 0x89, 0xbf, 0x65, 0x41,
+	0x66, 0x86, 0xa8, 0x9d, 0xc2, 0x32, 0x33, 0xe6, 0xad, 0x47, 0x0e, 0x5a, 0x4f, 0x0e, 0x92, 0x23,
+	0xdb, 0x32, 0xc6, 0x3d, 0xee, 0x08, 0xb7, 0x4a, 0xe2, 0x69, 0x5c, 0xf8, 0x4d, 0x82, 0xb5, 0xd0,
+	0xf1, 0xfe, 0x1d, 0xcb, 0x90, 0xb8, 0xa4, 0x84, 0x8f, 0x6d, 0xaa, 0x4a, 0x0f, 0xbd, 0x91, 0xb9,
+	0x94, 0x89, 0xd0, 0x9d, 0xa9, 0x33, 0x76, 0x8d, 0x9b, 0x1a, 0xd4, 0x98, 0xcc, 0x74, 0x86, 0xa0,
+	0x6f, 0x43, 0x9d, 0xa4, 0xcb, 0xf9, 0xc7, 0x0e, 0x6d, 0xfb, 0xba, 0xb9, 0x5e, 0x7f, 0x91, 0x61,
+	0xfd, 0x2d, 0xe1, 0xbd, 0xab, 0xff, 0xde, 0xed, 0xe7, 0x86, 0x1b, 0x79, 0xdc, 0xd7, 0xa3, 0xff,
+	0xc8, 0xd7, 0x63, 0x9f, 0xec, 0xeb, 0x33, 0x47, 0x78, 0x03, 0xea, 0xe2, 0x24, 0xfc, 0x0f, 0x77,
+	0x00, 0x71, 0x7a, 0x43, 0x4d, 0xee, 0xa8, 0x52, 0x3e, 0x52, 0x4c, 0x97, 0x9f, 0x3f, 0x36, 0x62,
+	0xcd, 0x55, 0x61, 0x5f, 0xbc, 0xfd, 0xab, 0x04, 0xa9, 0xe9, 0x8c, 0xd0, 0x06, 0xac, 0x35, 0x2b,
+	0xc7, 0xad, 0xba, 0x7e, 0x56, 0xd3, 0xba, 0x67, 0xad, 0x4e, 0x5b, 0xab, 0xd6, 0x8f, 0xea, 0x5a,
+	0x4d, 0x79, 0x12, 0xa4, 0x3a, 0xf5, 0xe3, 0x56, 0xfd, 0xa8, 0x5e, 0xad, 0xb4, 0x74, 0x45, 0x42,
+	0x4f, 0x01, 0xcd, 0xa8, 0xe6, 0x57, 0x07, 0xdd, 0x76, 0xe3, 0xac, 0xa3, 0xc8, 0x21, 0xbc, 0xec,
+	0xe3, 0x91, 0x10, 0xbe, 0xb7, 0xeb, 0xe1, 0x51, 0xb4, 0x02, 0x99, 0x19, 0x5e, 0x69, 0x34, 0x94,
+	0xd8, 0xf6, 0x3b, 0x88, 0xb6, 0xbd, 0xdf, 0x5d, 0xa5, 0x5d, 0xe9, 0xe8, 0xa1, 0x9e, 0x32, 0x90,
+	0x12, 0xe8, 0xc9, 0xe9, 0x19, 0x56, 0x24, 0xb4, 0x04, 0x49, 0x11, 0xd6, 0x2a, 0xe7, 0x8a, 0x8c,
+	0xb2, 0x00, 0x22, 0x7a, 0x55, 0xab, 0x9c, 0xbb, 0x55, 0x97, 0x21, 0x2d, 0xe2, 0xfd, 0x5d, 0x01,
+	0x44, 0xb7, 0x2d, 0xc8, 0x06, 0x2d, 0x1b, 0xbd, 0x84, 0xcd, 0x5a, 0xbd, 0xa3, 0x57, 0x5a, 0x55,
+	0xad, 0xdb, 0xd4, 0x74, 0x5c, 0xaf, 0x86, 0x0a, 0x6e, 0xc2, 0x7a, 0x58, 0xd0, 0x39, 0xc3, 0x47,
+	0x95, 0xaa, 0xa6, 0x48, 0x0f, 0x65, 0x9f, 0x9c, 0xb7, 0x4f, 0xab, 0x5a, 0x4b, 0xc7, 0x95, 0x86,
+	0x22, 0x6f, 0xff, 0x21, 0x41, 0xc2, 0x37, 0x23, 0xa4, 0xc2, 0x6a, 0xe7, 0x14, 0xeb, 0xdd, 0xd7,
+	0xda, 0x79, 0xa8, 0xc6, 0x0a, 0x64, 0xa6, 0x8c, 0x5e, 0x6f, 0xba, 0x27, 0x6f, 0xc0, 0xda, 0x4c,
+	0xdc, 0xae, 0x55, 0x74, 0xad, 0xe6, 0x51, 0x62, 0xc6, 0x53, 0x6a, 0x3a, 0x3c, 0x25, 0x12, 0x48,
+	0x99, 0x7d, 0xad, 0xaa, 0xa6, 0x44, 0x11, 0x82, 0xec, 0x94, 0xaa, 0x34, 0x34, 0xac, 0x2b, 0x31,
+	0xb4, 0x06, 0x2b, 0x53, 0x6c, 0x72, 0x09, 0x25, 0x5e, 0xfe, 0x5d, 0x86, 0xa5, 0x37, 0xee, 0xd2,
+	0x74, 0xa8, 0x7d, 0xc3, 0x7a, 0x14, 0xfd, 0x08, 0xcb, 0x21, 0x77, 0x44, 0x9f, 0x05, 0x17, 0xed,
+	0xe1, 0xff, 0xcb, 0x72, 0x9f, 0x7f, 0x44, 0xe5, 0x6f, 0xf1, 0xf7, 0x90, 0x09, 0xf8, 0x12, 0x2a,
+	0x84, 0x1f, 0xc8, 0xa2, 0x27, 0xe6, 0xfe, 0xff, 0xb7, 0x1a, 0xff, 0xe4, 0x1e, 0x28, 0xe1, 0xb7,
+	0x83, 0x42, 0x4d, 0x3d, 0xe2, 0x32, 0xb9, 0xad, 0x8f, 0xc9, 0xbc, 0x12, 0xbb, 0xd2, 0x61, 0xf4,
+	0x9d, 0x7c, 0xb3, 0x77, 0x11, 0x17, 0x3f, 0x57, 0xfb, 0x7f, 0x0d, 0x00, 0xeb, 0xfd, 0xde, 0x34,
+	0x0d, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -413,6 +1035,9 @@ type QuakeServiceClient interface {
 	ListEarthquakes(ctx context.Context, in *ListEarthquakesRequest, opts ...grpc.CallOption) (*ListEarthquakesResponse, error)
 	// Get earthquake by id.
 	GetEarthquake(ctx context.Context, in *GetEarthquakeRequest, opts ...grpc.CallOption) (*GetEarthquakeResponse, error)
+	// Watch earthquakes for given period and magnitude. Streams events when
+	// earthquakes are added, updated or deleted as data is refreshed.
+	WatchEarthquakes(ctx context.Context, in *WatchEarthquakesRequest, opts ...grpc.CallOption) (QuakeService_WatchEarthquakesClient, error)
 }
 
 type quakeServiceClient struct {
@@ -441,12 +1066,47 @@ func (c *quakeServiceClient) GetEarthquake(ctx context.Context, in *GetEarthquak
 	return out, nil
 }
 
+func (c *quakeServiceClient) WatchEarthquakes(ctx context.Context, in *WatchEarthquakesRequest, opts ...grpc.CallOption) (QuakeService_WatchEarthquakesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_QuakeService_serviceDesc.Streams[0], "/quake.api.v1.QuakeService/WatchEarthquakes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quakeServiceWatchEarthquakesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuakeService_WatchEarthquakesClient interface {
+	Recv() (*WatchEarthquakesResponse, error)
+	grpc.ClientStream
+}
+
+type quakeServiceWatchEarthquakesClient struct {
+	grpc.ClientStream
+}
+
+func (x *quakeServiceWatchEarthquakesClient) Recv() (*WatchEarthquakesResponse, error) {
+	m := new(WatchEarthquakesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // QuakeServiceServer is the server API for QuakeService service.
 type QuakeServiceServer interface {
 	// Get list of earthquakes for given period (like past day) and magnitude.
 	ListEarthquakes(context.Context, *ListEarthquakesRequest) (*ListEarthquakesResponse, error)
 	// Get earthquake by id.
 	GetEarthquake(context.Context, *GetEarthquakeRequest) (*GetEarthquakeResponse, error)
+	// Watch earthquakes for given period and magnitude. Streams events when
+	// earthquakes are added, updated or deleted as data is refreshed.
+	WatchEarthquakes(*WatchEarthquakesRequest, QuakeService_WatchEarthquakesServer) error
 }
 
 // UnimplementedQuakeServiceServer can be embedded to have forward compatible implementations.
@@ -459,6 +1119,9 @@ func (*UnimplementedQuakeServiceServer) ListEarthquakes(ctx context.Context, req
 func (*UnimplementedQuakeServiceServer) GetEarthquake(ctx context.Context, req *GetEarthquakeRequest) (*GetEarthquakeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEarthquake not implemented")
 }
+func (*UnimplementedQuakeServiceServer) WatchEarthquakes(req *WatchEarthquakesRequest, srv QuakeService_WatchEarthquakesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEarthquakes not implemented")
+}
 
 func RegisterQuakeServiceServer(s *grpc.Server, srv QuakeServiceServer) {
 	s.RegisterService(&_QuakeService_serviceDesc, srv)
@@ -500,6 +1163,27 @@ func _QuakeService_GetEarthquake_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuakeService_WatchEarthquakes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEarthquakesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuakeServiceServer).WatchEarthquakes(m, &quakeServiceWatchEarthquakesServer{stream})
+}
+
+type QuakeService_WatchEarthquakesServer interface {
+	Send(*WatchEarthquakesResponse) error
+	grpc.ServerStream
+}
+
+type quakeServiceWatchEarthquakesServer struct {
+	grpc.ServerStream
+}
+
+func (x *quakeServiceWatchEarthquakesServer) Send(m *WatchEarthquakesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _QuakeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "quake.api.v1.QuakeService",
 	HandlerType: (*QuakeServiceServer)(nil),
@@ -513,6 +1197,12 @@ var _QuakeService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _QuakeService_GetEarthquake_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEarthquakes",
+			Handler:       _QuakeService_WatchEarthquakes_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "quake/api/v1/quake_api.proto",
 }
@@ -120,6 +120,27 @@ func main() {
 		}
 		printEarthquakes(r.Collection)
 		break
+	case "WatchEarthquakes":
+		req, err := parseListEarthquakesRequest()
+		if err != nil {
+			log.Fatalf("bad request: %v", err)
+		}
+		// watching is not limited by the timeout (only connecting is)
+		stream, err := client.WatchEarthquakes(context.Background(),
+			&pb.WatchEarthquakesRequest{
+				Magnitude: req.Magnitude,
+				Past:      req.Past,
+			})
+		if err != nil {
+			log.Fatalf("failed to watch earthquakes: %v", err)
+		}
+		for {
+			r, err := stream.Recv()
+			if err != nil {
+				log.Fatalf("failed to receive events: %v", err)
+			}
+			printEvents(r.Events)
+		}
 	default:
 		printUsage()
 		os.Exit(1)
@@ -137,6 +158,9 @@ func printUsage() {
 	fmt.Println("     past: hour | day | 7days | 30days")
 	fmt.Println("     limit: {integer}")
 	fmt.Println("     details: true | false")
+	fmt.Println("  WatchEarthquakes <magnitude> <past>")
+	fmt.Println("     magnitude: significant | 4.5 | 2.5 | 1.0 | all")
+	fmt.Println("     past: hour | day | 7days | 30days")
 	fmt.Println("Optionally use env QUAKE_SERVICE to set server address.")
 	fmt.Println("Otherwise a default address is used: ", defaultAddress)
 }
@@ -147,6 +171,20 @@ func printEarthquakes(col *pb.EarthquakeCollection) {
 	}
 }
 
+func printEvents(events []*pb.EarthquakeEvent) {
+	for _, ev := range events {
+		switch ev.Type {
+		case pb.EventType_EVENT_TYPE_ADDED:
+			fmt.Print("added: ")
+		case pb.EventType_EVENT_TYPE_UPDATED:
+			fmt.Print("updated: ")
+		case pb.EventType_EVENT_TYPE_DELETED:
+			fmt.Print("deleted: ")
+		}
+		printEarthquake(ev.Feature)
+	}
+}
+
 func printEarthquake(eq *pb.Earthquake) {
 	timeFormatted := time.Unix(eq.Time, 0).Format(time.UnixDate)
 	fmt.Printf("%s at %s M%.1f near %s",
@@ -0,0 +1,94 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/navibyte/quake/pkg/earthquakes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// upstreamResourceType is a resource type on details of upstream errors
+//
+// Upstream errors are described by ResourceInfo (an URL as a resource name
+// and a reason as a description) instead of ErrorInfo, as ErrorInfo is not
+// available in the version of genproto that this module depends on.
+const upstreamResourceType = "upstream"
+
+// toStatus maps a repository error to a gRPC status error with details:
+//
+//   - earthquakes.ValidationError as InvalidArgument (with BadRequest)
+//   - earthquakes.UpstreamError as Unavailable (with ResourceInfo naming an
+//     upstream URL and a reason, and RetryInfo if a retry time is known)
+//   - context errors as DeadlineExceeded or Canceled
+//   - earthquakes.ErrNotFound as NotFound
+//   - earthquakes.ErrNotSupported as Unimplemented
+//   - other errors as Internal
+func toStatus(err error) error {
+	var verr *earthquakes.ValidationError
+	if errors.As(err, &verr) {
+		st := status.New(codes.InvalidArgument, verr.Error())
+		if len(verr.Violations) > 0 {
+			br := &errdetails.BadRequest{}
+			for _, v := range verr.Violations {
+				br.FieldViolations = append(br.FieldViolations,
+					&errdetails.BadRequest_FieldViolation{
+						Field:       v.Field,
+						Description: v.Description,
+					})
+			}
+			st = withDetails(st, br)
+		}
+		return st.Err()
+	}
+
+	var uerr *earthquakes.UpstreamError
+	if errors.As(err, &uerr) {
+		st := status.New(codes.Unavailable, uerr.Error())
+		details := []proto.Message{&errdetails.ResourceInfo{
+			ResourceType: upstreamResourceType,
+			ResourceName: uerr.URL,
+			Description:  uerr.Reason,
+		}}
+		if !uerr.RetryAt.IsZero() {
+			delay := time.Until(uerr.RetryAt)
+			if delay < 0 {
+				delay = 0
+			}
+			details = append(details, &errdetails.RetryInfo{
+				RetryDelay: ptypes.DurationProto(delay),
+			})
+		}
+		return withDetails(st, details...).Err()
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, earthquakes.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, earthquakes.ErrNotSupported):
+		return status.Error(codes.Unimplemented, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "internal error: %s", err.Error())
+	}
+}
+
+// withDetails returns a status with details attached (or the status as is if
+// attaching failed)
+func withDetails(st *status.Status, details ...proto.Message) *status.Status {
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
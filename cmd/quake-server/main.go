@@ -7,12 +7,18 @@ import (
 	"log"
 	"net"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/navibyte/quake/pkg/earthquakes"
+	"github.com/navibyte/quake/pkg/earthquakes/merge"
+	"github.com/navibyte/quake/pkg/earthquakes/usgs"
 	"google.golang.org/grpc"
 )
 
 const (
-	defaultPort = "50051"
+	defaultPort       = "50051"
+	defaultRepository = "usgs"
 )
 
 func main() {
@@ -22,14 +28,123 @@ func main() {
 		port = defaultPort
 	}
 
+	// QUAKE_REPOSITORY selects a backend for earthquake data or default
+	var repo earthquakes.Repository
+	switch name := os.Getenv("QUAKE_REPOSITORY"); name {
+	case "", defaultRepository, "merge":
+		// QUAKE_USGS_URL sets a base URL of the USGS web services (optional)
+		if url := os.Getenv("QUAKE_USGS_URL"); url != "" {
+			usgs.SetBaseURL(url)
+		}
+		// QUAKE_CACHE_DIR enables a disk store for cached data (optional)
+		if dir := os.Getenv("QUAKE_CACHE_DIR"); dir != "" {
+			if err := usgs.SetStoreDir(dir); err != nil {
+				log.Fatalf("failed to open cache dir: %v", err)
+			}
+		}
+		// QUAKE_MAX_STALE sets a maximum staleness for cached data (optional)
+		if value := os.Getenv("QUAKE_MAX_STALE"); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				log.Fatalf("invalid max stale: %v", err)
+			}
+			usgs.SetMaxStale(d)
+		}
+		// QUAKE_CACHE_TTL and QUAKE_CACHE_TTL_ADAPTIVE set a TTL policy
+		// for cached data (optional)
+		if policy := newTTLPolicy(); policy != nil {
+			usgs.SetTTLPolicy(policy)
+		}
+		// QUAKE_DERIVE_FEEDS tells to derive feeds by magnitude (optional)
+		if os.Getenv("QUAKE_DERIVE_FEEDS") == "true" {
+			usgs.SetDeriveFromAll(true)
+		}
+		// refresh hot cached data on background
+		stop := usgs.StartRefresher()
+		defer stop()
+		repo = usgs.NewRepository()
+		if name == "merge" {
+			repo = newMergeRepository(repo)
+		}
+	case "mock":
+		repo = &mockRepository{}
+	default:
+		log.Fatalf("unknown repository: %s", name)
+	}
+
 	// create the server with the actual service injected by registerServer()
 	lst, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		log.Fatalf("failed to open tcp listener: %v", err)
 	}
 	s := grpc.NewServer()
-	registerServer(s)
+	registerServer(s, repo)
 	if err := s.Serve(lst); err != nil {
 		log.Fatalf("failed to start server: %v", err)
 	}
 }
+
+// newMergeRepository returns a repository merging earthquakes of the USGS
+// (as the agency "us") with those of agencies publishing QuakeML on FDSN event
+// web services set by QUAKE_MERGE_SOURCES (like
+// "EMSC=https://www.seismicportal.eu/fdsnws/event/1/query"). Agencies are
+// preferred on the order listed after the USGS.
+func newMergeRepository(repo earthquakes.Repository) earthquakes.Repository {
+	sources := []merge.Source{merge.NewRepositorySource("us", repo)}
+	for _, value := range strings.Split(os.Getenv("QUAKE_MERGE_SOURCES"), ",") {
+		if value == "" {
+			continue
+		}
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			log.Fatalf("invalid merge source: %s", value)
+		}
+		sources = append(sources,
+			merge.NewFDSNSource(parts[0], parts[1], merge.FormatQuakeML, nil))
+	}
+	return merge.NewRepository(sources, nil, merge.DefaultTolerances)
+}
+
+// newTTLPolicy returns a TTL policy with overrides for feeds set by
+// QUAKE_CACHE_TTL (like "4.5_day=2m,all_month=30m") and adaptive between a
+// minimum and a maximum set by QUAKE_CACHE_TTL_ADAPTIVE (like "1m,30m"), or
+// nil if neither is set.
+func newTTLPolicy() usgs.TTLPolicy {
+	overrides := os.Getenv("QUAKE_CACHE_TTL")
+	adaptive := os.Getenv("QUAKE_CACHE_TTL_ADAPTIVE")
+	if overrides == "" && adaptive == "" {
+		return nil
+	}
+	ttls := make(map[string]time.Duration)
+	for _, value := range strings.Split(overrides, ",") {
+		if value == "" {
+			continue
+		}
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			log.Fatalf("invalid cache ttl: %s", value)
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			log.Fatalf("invalid cache ttl: %v", err)
+		}
+		ttls[parts[0]] = d
+	}
+	policy, err := usgs.FeedTTL(ttls, usgs.DefaultTTL)
+	if err != nil {
+		log.Fatalf("invalid cache ttl: %v", err)
+	}
+	if adaptive != "" {
+		parts := strings.Split(adaptive, ",")
+		if len(parts) != 2 {
+			log.Fatalf("invalid adaptive cache ttl: %s", adaptive)
+		}
+		min, err1 := time.ParseDuration(parts[0])
+		max, err2 := time.ParseDuration(parts[1])
+		if err1 != nil || err2 != nil || min > max {
+			log.Fatalf("invalid adaptive cache ttl: %s", adaptive)
+		}
+		policy = usgs.AdaptiveTTL(policy, min, max)
+	}
+	return policy
+}
@@ -4,12 +4,71 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
 )
 
+// mockRepository test implementation for the earthquakes.Repository
+type mockRepository struct{}
+
+func (*mockRepository) ListEarthquakes(ctx context.Context,
+	q earthquakes.Query) (*pb.EarthquakeCollection, string, error) {
+	return mockEarthquakeCollection(q.Limit, q.Details), "", nil
+}
+
+func (*mockRepository) ListEarthquakesFocusPosition(ctx context.Context,
+	q earthquakes.Query, pos *pb.GeoPointE7) (*pb.EarthquakeCollection, string, error) {
+	return mockEarthquakeCollection(q.Limit, q.Details), "", nil
+}
+
+func (*mockRepository) ListEarthquakesFocusBounds(ctx context.Context,
+	q earthquakes.Query, bounds *pb.GeoBoundsE7) (*pb.EarthquakeCollection, string, error) {
+	return mockEarthquakeCollection(q.Limit, q.Details), "", nil
+}
+
+func (*mockRepository) ListEarthquakesFocusArea(ctx context.Context,
+	q earthquakes.Query, area *pb.GeoMultiPolygonE7) (*pb.EarthquakeCollection, string, error) {
+	return mockEarthquakeCollection(q.Limit, q.Details), "", nil
+}
+
+func (*mockRepository) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+	return mockEarthquake(id, true), nil
+}
+
+func (*mockRepository) GetEarthquakeProducts(ctx context.Context, id string) (
+	*pb.EarthquakeProducts, error) {
+	return mockEarthquakeProducts(), nil
+}
+
+func (*mockRepository) WatchEarthquakes(q earthquakes.Query,
+	pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7, done <-chan struct{}) (
+	<-chan []*pb.EarthquakeEvent, error) {
+
+	// send mock earthquakes as added events, then wait until done
+	out := make(chan []*pb.EarthquakeEvent)
+	go func() {
+		defer close(out)
+		var events []*pb.EarthquakeEvent
+		for _, eq := range mockEarthquakeCollection(5, q.Details).Features {
+			events = append(events, &pb.EarthquakeEvent{
+				Type:    pb.EventType_EVENT_TYPE_ADDED,
+				Feature: eq,
+			})
+		}
+		select {
+		case out <- events:
+			<-done
+		case <-done:
+		}
+	}()
+	return out, nil
+}
+
 // mockEarthquakeCollection returns a dummy collection only for dev testing
 func mockEarthquakeCollection(count int, details bool) *pb.EarthquakeCollection {
 	var list []*pb.Earthquake
@@ -22,7 +81,7 @@ func mockEarthquakeCollection(count int, details bool) *pb.EarthquakeCollection
 			Url:           "http://example.org/",
 			Title:         "USGS Magnitude 1+ Earthquakes, Past Day",
 			Api:           "1.0",
-			Count:         15,
+			Count:         int32(count),
 			HttpStatus:    "200",
 		},
 		Bounds: &pb.GeoBoundsE7{
@@ -84,3 +143,26 @@ func mockEarthquakeDetails(id string) *pb.EarthquakeDetails {
 		Type:               pb.Type_TYPE_EARTHQUAKE,
 	}
 }
+
+func mockEarthquakeProducts() *pb.EarthquakeProducts {
+	info := &pb.ProductInfo{
+		Source:      "us",
+		Code:        "2013lgaz",
+		UpdatedTime: time.Now().Unix(),
+		Status:      "UPDATE",
+	}
+	return &pb.EarthquakeProducts{
+		Origin: &pb.OriginProduct{
+			Info:      info,
+			Position:  &pb.GeoPointE7{},
+			Time:      time.Now().Unix(),
+			Magnitude: 5.0,
+			MagType:   "mww",
+		},
+		Pager: &pb.PagerProduct{
+			Info:   info,
+			Alert:  pb.Alert_ALERT_ORANGE,
+			MaxMmi: 5.2,
+		},
+	}
+}
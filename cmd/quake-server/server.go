@@ -5,17 +5,18 @@ package main
 
 import (
 	"context"
+	"errors"
 
 	pb "github.com/navibyte/quake/api/v1"
-	"github.com/navibyte/quake/pkg/earthquakes/usgs"
+	"github.com/navibyte/quake/pkg/earthquakes"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // registerServer is used by main()
-func registerServer(s *grpc.Server) {
-	pb.RegisterQuakeServiceServer(s, &server{})
+func registerServer(s *grpc.Server, repo earthquakes.Repository) {
+	pb.RegisterQuakeServiceServer(s, &server{repo: repo})
 }
 
 // -----------------------------------------------------------------------------
@@ -23,32 +24,45 @@ func registerServer(s *grpc.Server) {
 // server implementation for the QuakeService
 type server struct {
 	pb.UnimplementedQuakeServiceServer
+
+	// repository providing earthquake data (like USGS or mock data)
+	repo earthquakes.Repository
 }
 
-func (*server) ListEarthquakes(ctx context.Context,
+func (s *server) ListEarthquakes(ctx context.Context,
 	req *pb.ListEarthquakesRequest) (*pb.ListEarthquakesResponse, error) {
 
-	// use (USGS) earthquake repository to get collection usings a right method
+	// validate a request before calling the repository
+	if err := validateListRequest(req); err != nil {
+		return nil, toStatus(err)
+	}
+
+	// query parameters for the repository
+	q := toQuery(req)
+
+	// use earthquake repository to get collection usings a right method
 	var col *pb.EarthquakeCollection
+	var next string
 	var err error
 	if pos := req.GetPosition(); pos != nil {
 		// list earthquakes nearest to the position
-		col, err = usgs.ListEarthquakesFocusPosition(
-			req.Magnitude, req.Past, int(req.Limit), req.Details, pos)
+		col, next, err = s.repo.ListEarthquakesFocusPosition(ctx, q, pos)
 	} else if bounds := req.GetBounds(); bounds != nil {
 		// list earthquakes inside bounds (and earthquakes nearest to the
 		// center of bounds coming first on the list)
-		col, err = usgs.ListEarthquakesFocusBounds(
-			req.Magnitude, req.Past, int(req.Limit), req.Details, bounds)
+		col, next, err = s.repo.ListEarthquakesFocusBounds(ctx, q, bounds)
+	} else if area := req.GetArea(); area != nil {
+		// list earthquakes inside an area of polygons (and earthquakes
+		// nearest to the center of bounds of the area coming first)
+		col, next, err = s.repo.ListEarthquakesFocusArea(ctx, q, area)
 	} else {
-		// list earthquakes on a order they are fetched from USGS
-		col, err = usgs.ListEarthquakes(
-			req.Magnitude, req.Past, int(req.Limit), req.Details)
+		// list earthquakes on a order they are provided by the repository
+		col, next, err = s.repo.ListEarthquakes(ctx, q)
 	}
 
 	// check if repository returned some error
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "internal error: %s", err.Error())
+		return nil, toStatus(err)
 	}
 	if col == nil {
 		return nil, status.Errorf(codes.Internal, "internal error: collection nil")
@@ -56,61 +70,140 @@ func (*server) ListEarthquakes(ctx context.Context,
 
 	// no error, so return valid response to RCP caller
 	res := &pb.ListEarthquakesResponse{
-		Collection: col,
+		Collection:    col,
+		NextPageToken: next,
 	}
 	return res, nil
 }
 
-func (*server) GetEarthquake(ctx context.Context,
+// toQuery converts parameters of a list request to a repository query
+func toQuery(req *pb.ListEarthquakesRequest) earthquakes.Query {
+	q := earthquakes.Query{
+		Magnitude:      req.Magnitude,
+		Past:           req.Past,
+		Limit:          int(req.Limit),
+		Details:        req.Details,
+		PageSize:       int(req.PageSize),
+		PageToken:      req.PageToken,
+		MaxDistance:    req.MaxDistanceMeters,
+		DistanceMetric: req.DistanceMetric,
+	}
+	if w := req.Window; w != nil {
+		q.StartTime = w.StartTime
+		q.EndTime = w.EndTime
+	}
+	if r := req.MagnitudeRange; r != nil {
+		if min := r.Min; min != nil {
+			q.MinMagnitude = &min.Value
+		}
+		if max := r.Max; max != nil {
+			q.MaxMagnitude = &max.Value
+		}
+	}
+	if s := req.Sort; s != nil {
+		q.SortKey = s.Key
+		q.SortDescending = s.Descending
+	}
+	if r := req.DepthRange; r != nil {
+		if min := r.Min; min != nil {
+			q.MinDepth = &min.Value
+		}
+		if max := r.Max; max != nil {
+			q.MaxDepth = &max.Value
+		}
+	}
+	return q
+}
+
+func (s *server) GetEarthquake(ctx context.Context,
 	req *pb.GetEarthquakeRequest) (*pb.GetEarthquakeResponse, error) {
 
-	// use (USGS) earthquake repository to get a specific earthquake (by id)
-	eq, err := usgs.GetEarthquake(req.Id)
+	// validate a request before calling the repository
+	if err := validateGetRequest(req); err != nil {
+		return nil, toStatus(err)
+	}
+
+	// use earthquake repository to get a specific earthquake (by id)
+	eq, err := s.repo.GetEarthquake(ctx, req.Id)
 
 	// check if repository returned some error
 	if err != nil {
-		if err == usgs.ErrNotFound {
+		if errors.Is(err, earthquakes.ErrNotFound) {
 			return nil, status.Errorf(codes.NotFound, "no earthquake for %s", req.Id)
 		}
-		return nil, status.Errorf(codes.Internal, "internal error: %s", err.Error())
+		return nil, toStatus(err)
 	}
 	if eq == nil {
 		return nil, status.Errorf(codes.Internal, "internal error: earthquake nil")
 	}
 
+	// products only if asked
+	var products *pb.EarthquakeProducts
+	if req.Products {
+		products, err = s.repo.GetEarthquakeProducts(ctx, eq.Id)
+		if err != nil {
+			if errors.Is(err, earthquakes.ErrNotSupported) {
+				return nil, status.Errorf(codes.Unimplemented, "products not supported")
+			}
+			return nil, toStatus(err)
+		}
+	}
+
 	// no error, so return valid response to RCP caller
 	res := &pb.GetEarthquakeResponse{
-		Feature: eq,
+		Feature:    eq,
+		Superseded: eq.Id != req.Id,
+		Products:   products,
 	}
 	return res, nil
 }
 
-// -----------------------------------------------------------------------------
-
-// mockServer test implementation for the QuakeService
-type mockServer struct {
-	pb.UnimplementedQuakeServiceServer
-}
+func (s *server) WatchEarthquakes(req *pb.WatchEarthquakesRequest,
+	stream pb.QuakeService_WatchEarthquakesServer) error {
 
-func (*mockServer) ListEarthquakes(ctx context.Context,
-	req *pb.ListEarthquakesRequest) (*pb.ListEarthquakesResponse, error) {
-
-	// return mock collection
-	res := &pb.ListEarthquakesResponse{
-		Collection: mockEarthquakeCollection(int(req.Limit), req.Details),
+	// validate a request before calling the repository
+	if err := validateWatchRequest(req); err != nil {
+		return toStatus(err)
 	}
-	return res, nil
 
-	//return nil, status.Errorf(codes.Unimplemented, "method ListEarthquakes not implemented")
-}
-func (*mockServer) GetEarthquake(ctx context.Context,
-	req *pb.GetEarthquakeRequest) (*pb.GetEarthquakeResponse, error) {
+	// query parameters for the repository
+	q := earthquakes.Query{
+		Magnitude: req.Magnitude,
+		Past:      req.Past,
+		Details:   req.Details,
+	}
 
-	// return mock earthquake
-	res := &pb.GetEarthquakeResponse{
-		Feature: mockEarthquake("Test123", true),
+	// start watching earthquake events (stopped when this method returns)
+	done := make(chan struct{})
+	defer close(done)
+	events, err := s.repo.WatchEarthquakes(q,
+		req.GetPosition(), req.GetBounds(), done)
+	if err != nil {
+		if errors.Is(err, earthquakes.ErrNotSupported) {
+			return status.Errorf(codes.Unimplemented, "watching not supported")
+		}
+		return toStatus(err)
 	}
-	return res, nil
 
-	//return nil, status.Errorf(codes.Unimplemented, "method GetEarthquake not implemented")
+	// send events to RCP caller until the caller or the repository ends
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case batch, ok := <-events:
+			if !ok {
+				return status.Errorf(codes.Aborted, "watching earthquakes ended")
+			}
+			if len(batch) == 0 {
+				continue
+			}
+			res := &pb.WatchEarthquakesResponse{
+				Events: batch,
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
 }
@@ -0,0 +1,323 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// notFoundRepository is a mock repository that never finds an earthquake
+type notFoundRepository struct {
+	mockRepository
+}
+
+func (*notFoundRepository) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+	return nil, earthquakes.ErrNotFound
+}
+
+// supersedingRepository is a mock repository that finds earthquakes by an id
+// associated to an earthquake having another preferred id
+type supersedingRepository struct {
+	mockRepository
+}
+
+func (*supersedingRepository) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+	return mockEarthquake("Preferred", true), nil
+}
+
+// waitingRepository is a mock repository that waits until a context is done
+type waitingRepository struct {
+	mockRepository
+}
+
+func (*waitingRepository) ListEarthquakes(ctx context.Context,
+	q earthquakes.Query) (*pb.EarthquakeCollection, string, error) {
+	<-ctx.Done()
+	return nil, "", ctx.Err()
+}
+
+// failingRepository is a mock repository that fails listing earthquakes
+type failingRepository struct {
+	mockRepository
+	err error
+}
+
+func (r *failingRepository) ListEarthquakes(ctx context.Context,
+	q earthquakes.Query) (*pb.EarthquakeCollection, string, error) {
+	return nil, "", r.err
+}
+
+func TestServerListEarthquakes(t *testing.T) {
+	s := &server{repo: &mockRepository{}}
+	res, err := s.ListEarthquakes(context.Background(),
+		&pb.ListEarthquakesRequest{
+			Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+			Past:      pb.Past_PAST_DAY,
+			Limit:     3,
+			Details:   true,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Collection.Features) != 3 {
+		t.Error("invalid feature count")
+	}
+	for _, eq := range res.Collection.Features {
+		if eq.Details == nil {
+			t.Error("has no details even if asked")
+		}
+	}
+}
+
+func TestServerGetEarthquake(t *testing.T) {
+	s := &server{repo: &mockRepository{}}
+	res, err := s.GetEarthquake(context.Background(),
+		&pb.GetEarthquakeRequest{Id: "Test123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Feature.Id != "Test123" || res.Superseded {
+		t.Error("invalid id")
+	}
+
+	// earthquake found by an old id should be marked superseded
+	s = &server{repo: &supersedingRepository{}}
+	res, err = s.GetEarthquake(context.Background(),
+		&pb.GetEarthquakeRequest{Id: "Test123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Superseded {
+		t.Error("should be superseded")
+	}
+
+	// repository errors should be mapped to gRPC status codes
+	s = &server{repo: &notFoundRepository{}}
+	_, err = s.GetEarthquake(context.Background(),
+		&pb.GetEarthquakeRequest{Id: "Test123"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestServerDeadline(t *testing.T) {
+	s := &server{repo: &waitingRepository{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := s.ListEarthquakes(ctx, &pb.ListEarthquakesRequest{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute)
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{earthquakes.ErrInvalidPageToken, codes.InvalidArgument},
+		{&earthquakes.UpstreamError{
+			URL:    "https://earthquake.usgs.gov/query",
+			Reason: earthquakes.ReasonInvalidData,
+			Err:    errors.New("invalid data"),
+		}, codes.Unavailable},
+		{&earthquakes.UpstreamError{
+			URL:     "https://earthquake.usgs.gov/feed",
+			Reason:  earthquakes.ReasonCircuitOpen,
+			RetryAt: retryAt,
+			Err:     errors.New("circuit open"),
+		}, codes.Unavailable},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{earthquakes.ErrNotFound, codes.NotFound},
+		{errors.New("unknown"), codes.Internal},
+	}
+	req := &pb.ListEarthquakesRequest{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+	}
+	for _, test := range tests {
+		s := &server{repo: &failingRepository{err: test.err}}
+		_, err := s.ListEarthquakes(context.Background(), req)
+		if status.Code(err) != test.code {
+			t.Errorf("expected %v for %v, got %v", test.code, test.err, err)
+		}
+	}
+
+	// details of errors
+	s := &server{repo: &failingRepository{err: earthquakes.ErrInvalidPageToken}}
+	_, err := s.ListEarthquakes(context.Background(), req)
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatal("expected BadRequest details")
+	}
+	br, ok := details[0].(*errdetails.BadRequest)
+	if !ok || br.FieldViolations[0].Field != "page_token" {
+		t.Error("invalid BadRequest details")
+	}
+	s = &server{repo: &failingRepository{err: tests[2].err}}
+	_, err = s.ListEarthquakes(context.Background(), req)
+	details = status.Convert(err).Details()
+	if len(details) != 2 {
+		t.Fatal("expected ResourceInfo and RetryInfo details")
+	}
+	ri, ok := details[0].(*errdetails.ResourceInfo)
+	if !ok || ri.ResourceName != "https://earthquake.usgs.gov/feed" ||
+		ri.Description != earthquakes.ReasonCircuitOpen {
+		t.Error("invalid ResourceInfo details")
+	}
+	retry, ok := details[1].(*errdetails.RetryInfo)
+	if !ok || retry.RetryDelay.Seconds < 50 || retry.RetryDelay.Seconds > 60 {
+		t.Error("invalid RetryInfo details")
+	}
+}
+
+func TestServerValidation(t *testing.T) {
+	s := &server{repo: &mockRepository{}}
+	valid := func() *pb.ListEarthquakesRequest {
+		return &pb.ListEarthquakesRequest{
+			Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+			Past:      pb.Past_PAST_DAY,
+		}
+	}
+	tests := []struct {
+		update func(req *pb.ListEarthquakesRequest)
+		field  string
+	}{
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Magnitude = pb.Magnitude_MAGNITUDE_UNSPECIFIED
+		}, "magnitude"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Past = pb.Past(99)
+		}, "past"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Limit = 1_000_000
+		}, "limit"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.PageSize = 1_000_000
+		}, "page_size"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Focus = &pb.ListEarthquakesRequest_Position{
+				Position: &pb.GeoPointE7{Latitude: 91_0000000},
+			}
+		}, "position.latitude"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Focus = &pb.ListEarthquakesRequest_Bounds{
+				Bounds: &pb.GeoBoundsE7{
+					MinLatitude: 10_0000000, MaxLatitude: -10_0000000,
+					MinLongitude: 0, MaxLongitude: 10_0000000,
+				},
+			}
+		}, "bounds"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Window = &pb.TimeWindow{StartTime: 200, EndTime: 100}
+		}, "window"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.MaxDistanceMeters = 100_000
+		}, "max_distance_meters"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Focus = &pb.ListEarthquakesRequest_Position{
+				Position: &pb.GeoPointE7{},
+			}
+			req.MaxDistanceMeters = -1
+		}, "max_distance_meters"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Focus = &pb.ListEarthquakesRequest_Area{
+				Area: &pb.GeoMultiPolygonE7{},
+			}
+		}, "area.polygons"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Focus = &pb.ListEarthquakesRequest_Area{
+				Area: &pb.GeoMultiPolygonE7{Polygons: []*pb.GeoPolygonE7{{
+					Exterior: &pb.GeoRingE7{Points: []*pb.GeoPointE7{{}, {Latitude: 10_0000000}}},
+				}}},
+			}
+		}, "area.polygons[0].exterior.points"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.DepthRange = &pb.DepthRange{
+				Min: &wrappers.FloatValue{Value: 70},
+				Max: &wrappers.FloatValue{Value: 10},
+			}
+		}, "depth_range"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.DistanceMetric = pb.DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL
+		}, "distance_metric"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Focus = &pb.ListEarthquakesRequest_Position{
+				Position: &pb.GeoPointE7{},
+			}
+			req.DistanceMetric = pb.DistanceMetric(99)
+		}, "distance_metric"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Sort = &pb.Sort{Key: pb.SortKey_SORT_KEY_DISTANCE}
+		}, "sort.key"},
+		{func(req *pb.ListEarthquakesRequest) {
+			req.Sort = &pb.Sort{Descending: true}
+		}, "sort.key"},
+	}
+	for _, test := range tests {
+		req := valid()
+		test.update(req)
+		_, err := s.ListEarthquakes(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for %s, got %v", test.field, err)
+			continue
+		}
+		details := status.Convert(err).Details()
+		if len(details) != 1 {
+			t.Errorf("expected BadRequest details for %s", test.field)
+			continue
+		}
+		br, ok := details[0].(*errdetails.BadRequest)
+		if !ok || len(br.FieldViolations) != 1 ||
+			br.FieldViolations[0].Field != test.field {
+			t.Errorf("invalid field violations for %s: %v", test.field, br)
+		}
+	}
+
+	// past is optional with a time window
+	req := valid()
+	req.Past = pb.Past_PAST_UNSPECIFIED
+	req.Window = &pb.TimeWindow{StartTime: 100}
+	if _, err := s.ListEarthquakes(context.Background(), req); err != nil {
+		t.Error(err)
+	}
+
+	// bounds may cross the antimeridian
+	req = valid()
+	req.Focus = &pb.ListEarthquakesRequest_Bounds{
+		Bounds: &pb.GeoBoundsE7{
+			MinLatitude: -40_0000000, MaxLatitude: 60_0000000,
+			MinLongitude: 170_0000000, MaxLongitude: -170_0000000,
+		},
+	}
+	if _, err := s.ListEarthquakes(context.Background(), req); err != nil {
+		t.Error(err)
+	}
+
+	// ids are checked by syntax
+	for _, id := range []string{"", "us 123", "../etc", "_abc"} {
+		_, err := s.GetEarthquake(context.Background(),
+			&pb.GetEarthquakeRequest{Id: id})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for id %q, got %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// maxLimit is a maximum limit (and a page size) for a list request (like on
+// the USGS FDSN event web service)
+const maxLimit = 20000
+
+// maxAreaPoints is a maximum number of points on all rings of an area
+const maxAreaPoints = 100000
+
+// idPattern matches valid earthquake ids (like "us70006vll" of the USGS or
+// "20200107_0000054" of the EMSC)
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,127}$`)
+
+// violations collects field violations of a request
+type violations []earthquakes.FieldViolation
+
+func (v *violations) add(field, description string) {
+	*v = append(*v, earthquakes.FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+// err returns a validation error if there are violations, or nil if none
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &earthquakes.ValidationError{Violations: v}
+}
+
+// validateListRequest validates parameters of a list request
+func validateListRequest(req *pb.ListEarthquakesRequest) error {
+	var v violations
+
+	// magnitude not needed if the minimum of the magnitude range is set, and
+	// past not needed if a time window is set
+	hasMin := req.MagnitudeRange != nil && req.MagnitudeRange.Min != nil
+	validateMagnitude(&v, "magnitude", req.Magnitude, hasMin)
+	validatePast(&v, "past", req.Past, req.Window != nil)
+	if req.Limit > maxLimit {
+		v.add("limit", fmt.Sprintf("must not be greater than %d", maxLimit))
+	}
+	if req.PageSize > maxLimit {
+		v.add("page_size", fmt.Sprintf("must not be greater than %d", maxLimit))
+	}
+	validateFocus(&v, req.GetPosition(), req.GetBounds())
+	if area := req.GetArea(); area != nil {
+		validateArea(&v, area)
+	}
+	if d := req.MaxDistanceMeters; d != 0 {
+		switch {
+		case math.IsNaN(d) || math.IsInf(d, 0) || d < 0:
+			v.add("max_distance_meters", "must be a positive finite number")
+		case req.GetPosition() == nil:
+			v.add("max_distance_meters", "requires the position focus")
+		}
+	}
+	if m := req.DistanceMetric; m != pb.DistanceMetric_DISTANCE_METRIC_UNSPECIFIED {
+		if _, ok := pb.DistanceMetric_name[int32(m)]; !ok {
+			v.add("distance_metric", "unknown value")
+		} else if req.Focus == nil {
+			v.add("distance_metric", "requires a focus")
+		}
+	}
+	if w := req.Window; w != nil {
+		if w.StartTime < 0 {
+			v.add("window.start_time", "must not be negative")
+		}
+		if w.EndTime < 0 {
+			v.add("window.end_time", "must not be negative")
+		}
+		if w.StartTime != 0 && w.EndTime != 0 && w.StartTime > w.EndTime {
+			v.add("window", "start_time must not be after end_time")
+		}
+	}
+	if r := req.MagnitudeRange; r != nil {
+		if r.Min != nil && !isFinite(r.Min.Value) {
+			v.add("magnitude_range.min", "must be a finite number")
+		}
+		if r.Max != nil && !isFinite(r.Max.Value) {
+			v.add("magnitude_range.max", "must be a finite number")
+		}
+		if r.Min != nil && r.Max != nil && r.Min.Value > r.Max.Value {
+			v.add("magnitude_range", "min must not be greater than max")
+		}
+	}
+	if s := req.Sort; s != nil {
+		if _, ok := pb.SortKey_name[int32(s.Key)]; !ok {
+			v.add("sort.key", "unknown value")
+		} else if s.Key == pb.SortKey_SORT_KEY_UNSPECIFIED && s.Descending {
+			v.add("sort.key", "must be set")
+		} else if s.Key == pb.SortKey_SORT_KEY_DISTANCE && req.Focus == nil {
+			v.add("sort.key", "requires a focus")
+		}
+	}
+	if r := req.DepthRange; r != nil {
+		if r.Min != nil && !isFinite(r.Min.Value) {
+			v.add("depth_range.min", "must be a finite number")
+		}
+		if r.Max != nil && !isFinite(r.Max.Value) {
+			v.add("depth_range.max", "must be a finite number")
+		}
+		if r.Min != nil && r.Max != nil && r.Min.Value > r.Max.Value {
+			v.add("depth_range", "min must not be greater than max")
+		}
+	}
+	return v.err()
+}
+
+// validateGetRequest validates parameters of a get request
+func validateGetRequest(req *pb.GetEarthquakeRequest) error {
+	var v violations
+	switch {
+	case req.Id == "":
+		v.add("id", "must be set")
+	case !idPattern.MatchString(req.Id):
+		v.add("id", "must be 1-128 letters, digits or characters _.:- "+
+			"starting with a letter or a digit")
+	}
+	return v.err()
+}
+
+// validateWatchRequest validates parameters of a watch request
+func validateWatchRequest(req *pb.WatchEarthquakesRequest) error {
+	var v violations
+	validateMagnitude(&v, "magnitude", req.Magnitude, false)
+	validatePast(&v, "past", req.Past, false)
+	validateFocus(&v, req.GetPosition(), req.GetBounds())
+	return v.err()
+}
+
+// validateMagnitude checks that a magnitude is known (and set unless optional)
+func validateMagnitude(v *violations, field string, magnitude pb.Magnitude,
+	optional bool) {
+
+	if _, ok := pb.Magnitude_name[int32(magnitude)]; !ok {
+		v.add(field, "unknown value")
+	} else if magnitude == pb.Magnitude_MAGNITUDE_UNSPECIFIED && !optional {
+		v.add(field, "must be set")
+	}
+}
+
+// validatePast checks that a past is known (and set unless optional)
+func validatePast(v *violations, field string, past pb.Past, optional bool) {
+	if _, ok := pb.Past_name[int32(past)]; !ok {
+		v.add(field, "unknown value")
+	} else if past == pb.Past_PAST_UNSPECIFIED && !optional {
+		v.add(field, "must be set")
+	}
+}
+
+// validateFocus checks a position or bounds of a request (if set), bounds
+// with min_longitude greater than max_longitude cross the antimeridian
+func validateFocus(v *violations, pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7) {
+	if pos != nil {
+		validateLatitude(v, "position.latitude", pos.Latitude)
+		validateLongitude(v, "position.longitude", pos.Longitude)
+	}
+	if bounds != nil {
+		validateLatitude(v, "bounds.min_latitude", bounds.MinLatitude)
+		validateLatitude(v, "bounds.max_latitude", bounds.MaxLatitude)
+		validateLongitude(v, "bounds.min_longitude", bounds.MinLongitude)
+		validateLongitude(v, "bounds.max_longitude", bounds.MaxLongitude)
+		if bounds.MinLatitude > bounds.MaxLatitude {
+			v.add("bounds", "min_latitude must not be greater than max_latitude")
+		}
+		if bounds.MinHeight > bounds.MaxHeight {
+			v.add("bounds", "min_height must not be greater than max_height")
+		}
+	}
+}
+
+// validateArea checks that an area has polygons with valid rings
+func validateArea(v *violations, area *pb.GeoMultiPolygonE7) {
+	if len(area.Polygons) == 0 {
+		v.add("area.polygons", "must not be empty")
+		return
+	}
+	count := 0
+	for i, p := range area.Polygons {
+		field := fmt.Sprintf("area.polygons[%d]", i)
+		count += validateRing(v, field+".exterior", p.Exterior)
+		for j, hole := range p.Holes {
+			count += validateRing(v, fmt.Sprintf("%s.holes[%d]", field, j), hole)
+		}
+	}
+	if count > maxAreaPoints {
+		v.add("area", fmt.Sprintf("must have at most %d points", maxAreaPoints))
+	}
+}
+
+// validateRing checks that a ring has at least 3 points (only the first point
+// not valid is reported), returns a number of points
+func validateRing(v *violations, field string, ring *pb.GeoRingE7) int {
+	points := ring.GetPoints()
+	if len(points) < 3 {
+		v.add(field+".points", "must have at least 3 points")
+	}
+	for k, pos := range points {
+		if pos == nil {
+			v.add(fmt.Sprintf("%s.points[%d]", field, k), "must be set")
+			break
+		}
+		if pos.Latitude < geolib.MinLatE7 || pos.Latitude > geolib.MaxLatE7 ||
+			pos.Longitude < geolib.MinLonE7 || pos.Longitude > geolib.MaxLonE7 {
+			v.add(fmt.Sprintf("%s.points[%d]", field, k),
+				"latitude and longitude must be in range")
+			break
+		}
+	}
+	return len(points)
+}
+
+func validateLatitude(v *violations, field string, lat int32) {
+	if lat < geolib.MinLatE7 || lat > geolib.MaxLatE7 {
+		v.add(field, "must be between -90_0000000 and 90_0000000")
+	}
+}
+
+func validateLongitude(v *violations, field string, lon int32) {
+	if lon < geolib.MinLonE7 || lon > geolib.MaxLonE7 {
+		v.add(field, "must be between -180_0000000 and 180_0000000")
+	}
+}
+
+func isFinite(value float32) bool {
+	f := float64(value)
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
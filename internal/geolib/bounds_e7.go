@@ -0,0 +1,70 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package geolib
+
+import (
+	"sort"
+)
+
+// fullLonE7 is a full circle of longitudes as E7 integer representation
+const fullLonE7 = int64(MaxLonE7) - int64(MinLonE7)
+
+// LonInRangeE7 returns true if a longitude is inside a longitude range from
+// west to east. When west is greater than east, the range crosses the
+// antimeridian (like from 170_0000000 to -170_0000000).
+func LonInRangeE7(lon, west, east int32) bool {
+	if west <= east {
+		return lon >= west && lon <= east
+	}
+	return lon >= west || lon <= east
+}
+
+// LonSpanE7 returns a width of a longitude range from west to east (crossing
+// the antimeridian if west is greater than east).
+func LonSpanE7(west, east int32) int64 {
+	span := int64(east) - int64(west)
+	if span < 0 {
+		span += fullLonE7
+	}
+	return span
+}
+
+// LonMidE7 returns a longitude at the middle of a longitude range from west
+// to east (crossing the antimeridian if west is greater than east).
+func LonMidE7(west, east int32) int32 {
+	return normalizeLonE7(int64(west) + LonSpanE7(west, east)/2)
+}
+
+// LonBoundsE7 returns the narrowest longitude range (as west and east) that
+// contains all longitudes, crossing the antimeridian if it's narrower so.
+// The argument lons must not be empty.
+func LonBoundsE7(lons []int32) (int32, int32) {
+	sorted := append([]int32(nil), lons...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// the range is the complement of the largest gap between longitudes,
+	// first checking the gap across the antimeridian (not crossing then)
+	first, last := sorted[0], sorted[len(sorted)-1]
+	west, east := first, last
+	maxGap := int64(first) + fullLonE7 - int64(last)
+	for i := 1; i < len(sorted); i++ {
+		if gap := int64(sorted[i]) - int64(sorted[i-1]); gap > maxGap {
+			maxGap = gap
+			west, east = sorted[i], sorted[i-1]
+		}
+	}
+	return west, east
+}
+
+// normalizeLonE7 normalizes a longitude to the range
+// [-180_0000000, 180_0000000]
+func normalizeLonE7(lon int64) int32 {
+	for lon > MaxLonE7 {
+		lon -= fullLonE7
+	}
+	for lon < MinLonE7 {
+		lon += fullLonE7
+	}
+	return int32(lon)
+}
@@ -10,16 +10,27 @@ import (
 
 const factorE7 = 1e7
 
+// EarthRadius is a mean radius of the earth (meters) used by distances.
+const EarthRadius = float64(6371000)
+
+// Limits for latitude and longitude as E7 integer representations.
+const (
+	MinLatE7 = -90_0000000
+	MaxLatE7 = 90_0000000
+	MinLonE7 = -180_0000000
+	MaxLonE7 = 180_0000000
+)
+
 // LatFromE7 converts E7 integer representation to latitude.
 // The argument lat must be (and clipped) on the range [-90_0000000, 90_0000000. 
 func LatFromE7(e7 int32) float64 {
-	return float64(mathlib.ClipInt32(e7, -90_0000000, 90_0000000)) / factorE7
+	return float64(mathlib.ClipInt32(e7, MinLatE7, MaxLatE7)) / factorE7
 }
 
 // LonFromE7 converts E7 integer representation to latitude.
 // The argument lon must be (and clipped) on the range [-180_0000000, 180_0000000]. 
 func LonFromE7(e7 int32) float64 {
-	return float64(mathlib.ClipInt32(e7, -180_0000000, 180_0000000)) / factorE7
+	return float64(mathlib.ClipInt32(e7, MinLonE7, MaxLonE7)) / factorE7
 }
 
 // LatToE7 converts latitude to E7 integer representation.
@@ -45,8 +56,6 @@ func Distance(lat1, lon1, lat2, lon2 float64) float64 {
 	// using "haversine" formula
 	// see: http://mathforum.org/library/drmath/view/51879.html
 	
-	const earthRadius = float64(6371000)
-
 	lat1Rad := mathlib.ToRad(lat1)
 	lat2Rad := mathlib.ToRad(lat2)
 	dlat := mathlib.ToRad(lat2 - lat1)
@@ -56,5 +65,48 @@ func Distance(lat1, lon1, lat2, lon2 float64) float64 {
 		 math.Sin(dlon/2) * math.Sin(dlon/2)
   	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a)) 
 	
-	return earthRadius * c
+	return EarthRadius * c
+}
+
+// HypocentralDistanceE7 returns a straight line distance between two points
+// with heights (centimeters, negative values meaning depth). Result is meters.
+func HypocentralDistanceE7(lat1, lon1, height1, lat2, lon2, height2 int32) float64 {
+	return HypocentralDistance(LatFromE7(lat1), LonFromE7(lon1), float64(height1)/100,
+		LatFromE7(lat2), LonFromE7(lon2), float64(height2)/100)
+}
+
+// HypocentralDistance returns a straight line distance between two points
+// with heights (meters, negative values meaning depth). Result is meters.
+func HypocentralDistance(lat1, lon1, height1, lat2, lon2, height2 float64) float64 {
+	// a chord between points at radiuses r1 and r2 by the central angle of
+	// the surface distance (the law of cosines in a stable form)
+	r1 := EarthRadius + height1
+	r2 := EarthRadius + height2
+	sinHalf := math.Sin(Distance(lat1, lon1, lat2, lon2) / EarthRadius / 2)
+	dr := r1 - r2
+	return math.Sqrt(dr*dr + 4*r1*r2*sinHalf*sinHalf)
+}
+
+// BearingE7 returns an initial bearing from the first point to the second
+// point. Result is degrees clockwise from north on the range [0.0, 360.0[.
+func BearingE7(lat1, lon1, lat2, lon2 int32) float64 {
+	return Bearing(LatFromE7(lat1), LonFromE7(lon1),
+		LatFromE7(lat2), LonFromE7(lon2))
+}
+
+// Bearing returns an initial bearing from the first point to the second
+// point. Result is degrees clockwise from north on the range [0.0, 360.0[.
+func Bearing(lat1, lon1, lat2, lon2 float64) float64 {
+	// see: https://www.movable-type.co.uk/scripts/latlong.html
+	lat1Rad := mathlib.ToRad(lat1)
+	lat2Rad := mathlib.ToRad(lat2)
+	dlon := mathlib.ToRad(lon2 - lon1)
+	y := math.Sin(dlon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) -
+		math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(dlon)
+	bearing := math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+	if bearing >= 360 {
+		bearing = 0
+	}
+	return bearing
 }
@@ -0,0 +1,48 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package geolib
+
+// RingE7 is a closed ring of points (latitudes and longitudes as E7 integer
+// representations on same indexes). Edges between points are straight lines
+// on latitude and longitude coordinates, an edge crosses the antimeridian when
+// longitudes of its points differ more than 180 degrees. A ring around a pole
+// must have the pole as points of the ring (like GeoJSON polygons of the
+// Antarctica).
+type RingE7 struct {
+	Lats []int32
+	Lons []int32
+}
+
+// ContainsE7 returns true if a point is inside a ring (by the even-odd rule).
+// Points on edges may be inside or not.
+func (r RingE7) ContainsE7(lat, lon int32) bool {
+	// count edges crossing a ray from the point towards the north pole, with
+	// longitudes relative to the first point of an edge
+	inside := false
+	n := len(r.Lats)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		d := wrapLonE7(int64(r.Lons[i]) - int64(r.Lons[j]))
+		t := wrapLonE7(int64(lon) - int64(r.Lons[j]))
+		if (t < 0) != (t < d) {
+			crossLat := float64(r.Lats[j]) +
+				(float64(r.Lats[i])-float64(r.Lats[j]))*float64(t)/float64(d)
+			if crossLat > float64(lat) {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// wrapLonE7 wraps a longitude difference to the range
+// ]-180_0000000, 180_0000000]
+func wrapLonE7(d int64) int64 {
+	for d > MaxLonE7 {
+		d -= fullLonE7
+	}
+	for d <= MinLonE7 {
+		d += fullLonE7
+	}
+	return d
+}
@@ -21,6 +21,15 @@ func NewCursor(result gjson.Result) Cursor {
 	return Cursor{result: result}
 }
 
+// ParseBytes validates JSON data and returns a new cursor for the root value
+// of it (or false if data is not valid JSON).
+func ParseBytes(data []byte) (Cursor, bool) {
+	if !gjson.ValidBytes(data) {
+		return Cursor{}, false
+	}
+	return NewCursor(gjson.ParseBytes(data)), true
+}
+
 // Result returns the gjson.Result the cursor is pointing at.
 func (c Cursor) Result() gjson.Result {
 	return c.result
@@ -52,6 +61,16 @@ func (c Cursor) ForEachArray(path string, iterator func(value Cursor) bool) {
 	}
 }
 
+// ForEach iterates through elements of an array the cursor points to (until
+// the iterator returns false).
+func (c Cursor) ForEach(iterator func(value Cursor) bool) {
+	if c.result.IsArray() {
+		c.result.ForEach(func(_ gjson.Result, v gjson.Result) bool {
+			return iterator(NewCursor(v))
+		})
+	}
+}
+
 // Exists returns true if the cursor points to an existing JSON value.
 func (c Cursor) Exists() bool {
 	return c.result.Exists()
@@ -67,6 +86,11 @@ func (c Cursor) IsArray() bool {
 	return c.result.IsArray()
 }
 
+// IsNumber returns true if an element is a JSON number.
+func (c Cursor) IsNumber(path string) bool {
+	return c.result.Get(path).Type == gjson.Number
+}
+
 // String returns an element as a string.
 func (c Cursor) String(path string) string {
 	return c.result.Get(path).String()
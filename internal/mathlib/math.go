@@ -51,6 +51,14 @@ func MaxInt32(a, b int32) int32 {
 	return b
 }
 
+// MaxInt64 returns maximum value of a and b.
+func MaxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // ToRad converts degrees to radians.
 func ToRad(value float64) float64 {
 	return value * math.Pi / float64(180)
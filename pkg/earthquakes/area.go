@@ -0,0 +1,224 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package earthquakes
+
+import (
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/internal/jsonlib"
+	"github.com/navibyte/quake/internal/mathlib"
+)
+
+// ErrInvalidArea is returned when GeoJSON data for an area has no valid
+// polygons
+var ErrInvalidArea error = &ValidationError{
+	Message: "invalid GeoJSON polygon data",
+	Violations: []FieldViolation{
+		{Field: "area", Description: "must be GeoJSON data with valid polygons"},
+	},
+}
+
+// errEmptyArea is returned when an area has no valid polygons
+var errEmptyArea error = &ValidationError{
+	Violations: []FieldViolation{
+		{Field: "area", Description: "must have a polygon with at least 3 points on the exterior ring"},
+	},
+}
+
+// Area is an area of polygons (with holes) for filtering earthquakes.
+type Area struct {
+	polygons []areaPolygon
+	bounds   *pb.GeoBoundsE7
+}
+
+type areaPolygon struct {
+	exterior geolib.RingE7
+	holes    []geolib.RingE7
+}
+
+// NewArea creates an area of polygons, polygons without an exterior ring (of
+// at least 3 points) are ignored. Returns a ValidationError if no polygons.
+func NewArea(mp *pb.GeoMultiPolygonE7) (*Area, error) {
+	a := &Area{}
+	var lons []int32
+	for _, p := range mp.GetPolygons() {
+		if len(p.GetExterior().GetPoints()) < 3 {
+			continue
+		}
+		polygon := areaPolygon{exterior: toRing(p.Exterior)}
+		for _, hole := range p.Holes {
+			if len(hole.GetPoints()) >= 3 {
+				polygon.holes = append(polygon.holes, toRing(hole))
+			}
+		}
+		a.polygons = append(a.polygons, polygon)
+
+		// bounds by points of exterior rings
+		for _, pos := range p.Exterior.Points {
+			if a.bounds == nil {
+				a.bounds = &pb.GeoBoundsE7{
+					MinLatitude: pos.Latitude,
+					MaxLatitude: pos.Latitude,
+				}
+			}
+			a.bounds.MinLatitude = mathlib.MinInt32(a.bounds.MinLatitude, pos.Latitude)
+			a.bounds.MaxLatitude = mathlib.MaxInt32(a.bounds.MaxLatitude, pos.Latitude)
+			lons = append(lons, pos.Longitude)
+		}
+	}
+	if len(a.polygons) == 0 {
+		return nil, errEmptyArea
+	}
+
+	// edges are shorter than 180 degrees of longitude, so they are inside the
+	// narrowest longitude range of points if it's not wider than that
+	west, east := geolib.LonBoundsE7(lons)
+	if geolib.LonSpanE7(west, east) > geolib.MaxLonE7 {
+		west, east = geolib.MinLonE7, geolib.MaxLonE7
+	}
+	a.bounds.MinLongitude, a.bounds.MaxLongitude = west, east
+	return a, nil
+}
+
+func toRing(ring *pb.GeoRingE7) geolib.RingE7 {
+	r := geolib.RingE7{
+		Lats: make([]int32, len(ring.Points)),
+		Lons: make([]int32, len(ring.Points)),
+	}
+	for i, pos := range ring.Points {
+		r.Lats[i] = pos.Latitude
+		r.Lons[i] = pos.Longitude
+	}
+	return r
+}
+
+// Contains returns true if a position is inside some polygon of an area (and
+// not inside holes of it).
+func (a *Area) Contains(pos *pb.GeoPointE7) bool {
+	if !InBounds(pos, a.bounds) {
+		return false
+	}
+	for _, p := range a.polygons {
+		if p.exterior.ContainsE7(pos.Latitude, pos.Longitude) && !p.inHole(pos) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p areaPolygon) inHole(pos *pb.GeoPointE7) bool {
+	for _, hole := range p.holes {
+		if hole.ContainsE7(pos.Latitude, pos.Longitude) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns bounds containing an area (with all longitudes if the area
+// spans over 180 degrees of longitude). Bounds must not be modified.
+func (a *Area) Bounds() *pb.GeoBoundsE7 {
+	return a.bounds
+}
+
+// ParseGeoJSONArea parses an area from GeoJSON data of a Polygon or a
+// MultiPolygon geometry, or a Feature, a FeatureCollection or a
+// GeometryCollection containing them (other geometries are ignored).
+func ParseGeoJSONArea(data []byte) (*pb.GeoMultiPolygonE7, error) {
+	root, ok := jsonlib.ParseBytes(data)
+	if !ok {
+		return nil, ErrInvalidArea
+	}
+	mp := &pb.GeoMultiPolygonE7{}
+	if err := addGeoJSONPolygons(mp, root); err != nil {
+		return nil, err
+	}
+	if len(mp.Polygons) == 0 {
+		return nil, ErrInvalidArea
+	}
+	return mp, nil
+}
+
+func addGeoJSONPolygons(mp *pb.GeoMultiPolygonE7, c jsonlib.Cursor) error {
+	var err error
+	addAll := func(path string) {
+		c.Get(path).ForEach(func(child jsonlib.Cursor) bool {
+			err = addGeoJSONPolygons(mp, child)
+			return err == nil
+		})
+	}
+	switch c.String("type") {
+	case "FeatureCollection":
+		addAll("features")
+	case "GeometryCollection":
+		addAll("geometries")
+	case "Feature":
+		if geometry := c.Get("geometry"); geometry.IsObject() {
+			err = addGeoJSONPolygons(mp, geometry)
+		}
+	case "Polygon":
+		var p *pb.GeoPolygonE7
+		if p, err = parseGeoJSONPolygon(c.Get("coordinates")); err == nil {
+			mp.Polygons = append(mp.Polygons, p)
+		}
+	case "MultiPolygon":
+		c.Get("coordinates").ForEach(func(coords jsonlib.Cursor) bool {
+			var p *pb.GeoPolygonE7
+			if p, err = parseGeoJSONPolygon(coords); err == nil {
+				mp.Polygons = append(mp.Polygons, p)
+			}
+			return err == nil
+		})
+	}
+	return err
+}
+
+// parseGeoJSONPolygon parses polygon coordinates (an exterior ring and holes
+// as arrays of [longitude, latitude] positions)
+func parseGeoJSONPolygon(coords jsonlib.Cursor) (*pb.GeoPolygonE7, error) {
+	if !coords.IsArray() {
+		return nil, ErrInvalidArea
+	}
+	var p pb.GeoPolygonE7
+	var err error
+	coords.ForEach(func(rc jsonlib.Cursor) bool {
+		ring := &pb.GeoRingE7{}
+		if !rc.IsArray() {
+			err = ErrInvalidArea
+			return false
+		}
+		rc.ForEach(func(pc jsonlib.Cursor) bool {
+			if !pc.IsNumber("0") || !pc.IsNumber("1") {
+				err = ErrInvalidArea
+				return false
+			}
+			lon, lat := pc.Float64("0"), pc.Float64("1")
+			if lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+				err = ErrInvalidArea
+				return false
+			}
+			ring.Points = append(ring.Points, &pb.GeoPointE7{
+				Latitude:  geolib.LatToE7(lat),
+				Longitude: geolib.LonToE7(lon),
+			})
+			return true
+		})
+		if err == nil && len(ring.Points) < 3 {
+			err = ErrInvalidArea
+		}
+		if p.Exterior == nil {
+			p.Exterior = ring
+		} else {
+			p.Holes = append(p.Holes, ring)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p.Exterior == nil {
+		return nil, ErrInvalidArea
+	}
+	return &p, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package earthquakes
+
+import (
+	"testing"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestParseGeoJSONArea(t *testing.T) {
+	// a polygon crossing the antimeridian (with a hole), another one on a
+	// multipolygon, and a point ignored
+	mp, err := ParseGeoJSONArea([]byte(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [
+				[[170, -40], [-170, -40], [-170, -10], [170, -10], [170, -40]],
+				[[-173, -19.5], [-171, -19.5], [-171, -18], [-173, -18], [-173, -19.5]]
+			]}},
+			{"type": "Feature", "geometry": {"type": "MultiPolygon", "coordinates": [
+				[[[-179, 50], [-177, 50], [-177, 52], [-179, 52], [-179, 50]]]
+			]}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mp.Polygons) != 2 || len(mp.Polygons[0].Holes) != 1 {
+		t.Fatalf("invalid area %v", mp)
+	}
+	a, err := NewArea(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := a.Bounds(); b.MinLongitude != 170_0000000 || b.MaxLongitude != -170_0000000 {
+		t.Errorf("invalid bounds %v", b)
+	}
+	for _, test := range []struct {
+		lat, lon int32
+		inside   bool
+	}{
+		{-30_0000000, 180_0000000, true},
+		{-30_0000000, -175_0000000, true},
+		{-19_0000000, -172_0000000, false}, // inside a hole
+		{51_0000000, -178_0000000, true},
+		{0, 0, false},
+	} {
+		pos := &pb.GeoPointE7{Latitude: test.lat, Longitude: test.lon}
+		if a.Contains(pos) != test.inside {
+			t.Errorf("invalid containment for %v", pos)
+		}
+	}
+
+	// not valid areas
+	for _, data := range []string{
+		`{"type": "Polygon"`,
+		`{"type": "Point", "coordinates": [0, 0]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0]]]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 91], [0, 0]]]}`,
+	} {
+		if _, err := ParseGeoJSONArea([]byte(data)); err != ErrInvalidArea {
+			t.Errorf("expected ErrInvalidArea for %s", data)
+		}
+	}
+}
+
+func TestAreaPole(t *testing.T) {
+	// a ring around the south pole (like the Antarctica on GeoJSON)
+	mp, err := ParseGeoJSONArea([]byte(`{"type": "Polygon", "coordinates": [
+		[[-180, -60], [0, -65], [180, -60], [180, -90], [-180, -90], [-180, -60]]
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewArea(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		lat, lon int32
+		inside   bool
+	}{
+		{-70_0000000, 0, true},
+		{-70_0000000, 179_9000000, true},
+		{-62_0000000, 0, false},
+		{-50_0000000, -120_0000000, false},
+	} {
+		pos := &pb.GeoPointE7{Latitude: test.lat, Longitude: test.lon}
+		if a.Contains(pos) != test.inside {
+			t.Errorf("invalid containment for %v", pos)
+		}
+	}
+}
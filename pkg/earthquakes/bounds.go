@@ -0,0 +1,82 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package earthquakes
+
+import (
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/internal/mathlib"
+)
+
+// InBounds returns true if a position is inside bounds. Bounds with the
+// minimum longitude greater than the maximum cross the antimeridian. Heights
+// of positions are checked if bounds has a height range.
+func InBounds(pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7) bool {
+	if HasHeightRange(bounds) &&
+		(pos.Height < bounds.MinHeight || pos.Height > bounds.MaxHeight) {
+		return false
+	}
+	return pos.Latitude >= bounds.MinLatitude &&
+		pos.Latitude <= bounds.MaxLatitude &&
+		geolib.LonInRangeE7(pos.Longitude,
+			bounds.MinLongitude, bounds.MaxLongitude)
+}
+
+// HasHeightRange returns true if bounds has a height range (both min and max
+// heights as 0 means no height range).
+func HasHeightRange(bounds *pb.GeoBoundsE7) bool {
+	return bounds.MinHeight != 0 || bounds.MaxHeight != 0
+}
+
+// InDepthRange returns true if a position (with height as negative depth in
+// centimeters) is inside a depth range (kilometers, min and max optional).
+func InDepthRange(pos *pb.GeoPointE7, min, max *float32) bool {
+	depth := -float64(pos.Height) / 100000
+	if min != nil && depth < float64(*min) {
+		return false
+	}
+	if max != nil && depth > float64(*max) {
+		return false
+	}
+	return true
+}
+
+// BoundsCenter returns a center of bounds (that may cross the antimeridian).
+func BoundsCenter(bounds *pb.GeoBoundsE7) *pb.GeoPointE7 {
+	return &pb.GeoPointE7{
+		Latitude:  midInt32(bounds.MinLatitude, bounds.MaxLatitude),
+		Longitude: geolib.LonMidE7(bounds.MinLongitude, bounds.MaxLongitude),
+		Height:    midInt32(bounds.MinHeight, bounds.MaxHeight),
+	}
+}
+
+// BoundsOf returns the minimal bounds containing positions of earthquakes,
+// crossing the antimeridian if narrower so, or nil if there are none.
+func BoundsOf(features []*pb.Earthquake) *pb.GeoBoundsE7 {
+	if len(features) == 0 {
+		return nil
+	}
+	first := features[0].Position
+	bounds := &pb.GeoBoundsE7{
+		MinLatitude: first.Latitude,
+		MinHeight:   first.Height,
+		MaxLatitude: first.Latitude,
+		MaxHeight:   first.Height,
+	}
+	lons := make([]int32, len(features))
+	for i, eq := range features {
+		pos := eq.Position
+		bounds.MinLatitude = mathlib.MinInt32(bounds.MinLatitude, pos.Latitude)
+		bounds.MinHeight = mathlib.MinInt32(bounds.MinHeight, pos.Height)
+		bounds.MaxLatitude = mathlib.MaxInt32(bounds.MaxLatitude, pos.Latitude)
+		bounds.MaxHeight = mathlib.MaxInt32(bounds.MaxHeight, pos.Height)
+		lons[i] = pos.Longitude
+	}
+	bounds.MinLongitude, bounds.MaxLongitude = geolib.LonBoundsE7(lons)
+	return bounds
+}
+
+func midInt32(min, max int32) int32 {
+	return int32((int64(min) + int64(max)) / 2)
+}
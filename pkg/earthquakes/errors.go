@@ -0,0 +1,78 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package earthquakes
+
+import (
+	"strings"
+	"time"
+)
+
+// FieldViolation describes a field of a query (named like on the API, for
+// example "page_token") that is not valid.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError is returned when a query or an id is not valid.
+type ValidationError struct {
+	// Message describes the error (if empty violations are described).
+	Message string
+
+	// Violations lists fields not valid (if known).
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	descs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descs[i] = v.Field + ": " + v.Description
+	}
+	return "invalid argument: " + strings.Join(descs, "; ")
+}
+
+// Reasons for upstream errors.
+const (
+	// ReasonUnavailable is a reason when an upstream service could not be
+	// reached or it responded with an error.
+	ReasonUnavailable = "UPSTREAM_UNAVAILABLE"
+
+	// ReasonInvalidData is a reason when an upstream service responded with
+	// data that could not be parsed.
+	ReasonInvalidData = "UPSTREAM_INVALID_DATA"
+
+	// ReasonCircuitOpen is a reason when requests to an upstream service are
+	// rejected by a circuit breaker after failures.
+	ReasonCircuitOpen = "UPSTREAM_CIRCUIT_OPEN"
+)
+
+// UpstreamError is returned when data could not be fetched from an upstream
+// service (like the USGS) of a repository.
+type UpstreamError struct {
+	// URL is an URL of the upstream resource (if known).
+	URL string
+
+	// Reason is one of the reasons (like ReasonUnavailable).
+	Reason string
+
+	// RetryAt is the earliest time that a retry is worth it (if known).
+	RetryAt time.Time
+
+	// Err is an underlying error.
+	Err error
+}
+
+func (e *UpstreamError) Error() string {
+	if e.URL == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + " (" + e.URL + ")"
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package merge
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+)
+
+// Tolerances define how close earthquakes reported by different agencies must
+// be to associate them as the same earthquake.
+type Tolerances struct {
+	// Time is a maximum difference between origin times (seconds).
+	Time int64
+
+	// Distance is a maximum distance between epicenters (meters).
+	Distance float64
+
+	// Magnitude is a maximum difference between magnitudes.
+	Magnitude float32
+}
+
+// DefaultTolerances are tolerances typical for associating earthquakes
+// reported by global and regional agencies.
+var DefaultTolerances = Tolerances{
+	Time:      16,
+	Distance:  100_000,
+	Magnitude: 0.5,
+}
+
+// reported is an earthquake reported by a source (index on priority order)
+type reported struct {
+	eq     *pb.Earthquake
+	agency string
+	source int
+}
+
+// event is a set of earthquakes reported by different sources associated as
+// the same earthquake, the first one is the preferred solution
+type event struct {
+	members []reported
+}
+
+func (e *event) preferred() *pb.Earthquake {
+	return e.members[0].eq
+}
+
+func (e *event) hasSource(source int) bool {
+	for _, m := range e.members {
+		if m.source == source {
+			return true
+		}
+	}
+	return false
+}
+
+// associate associates earthquakes reported by sources (on priority order
+// from the preferred source) to events, returned sorted by time
+func associate(reports [][]reported, tol Tolerances) []*event {
+	var events []*event
+	for _, eqs := range reports {
+		var added []*event
+		for _, r := range eqs {
+			if r.eq.Position == nil {
+				continue
+			}
+			if e := findEvent(events, r, tol); e != nil {
+				e.members = append(e.members, r)
+			} else {
+				added = append(added, &event{members: []reported{r}})
+			}
+		}
+
+		// events of a source are associated only with events of preferred
+		// sources, so events added are searchable after all are associated
+		events = append(events, added...)
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].preferred().Time < events[j].preferred().Time
+		})
+	}
+	return events
+}
+
+// findEvent returns an event (not yet having an earthquake from the same
+// source) best matching an earthquake within tolerances, or nil if none
+func findEvent(events []*event, r reported, tol Tolerances) *event {
+	var best *event
+	var bestScore float64
+	first := sort.Search(len(events), func(i int) bool {
+		return events[i].preferred().Time >= r.eq.Time-tol.Time
+	})
+	for _, e := range events[first:] {
+		pref := e.preferred()
+		if pref.Time > r.eq.Time+tol.Time {
+			break
+		}
+		if e.hasSource(r.source) || !withinMagnitude(pref, r.eq, tol) {
+			continue
+		}
+		dist := geolib.DistanceE7(pref.Position.Latitude, pref.Position.Longitude,
+			r.eq.Position.Latitude, r.eq.Position.Longitude)
+		if dist > tol.Distance {
+			continue
+		}
+
+		// the closest event (relative to tolerances) on time and distance
+		var score float64
+		if tol.Distance > 0 {
+			score += dist / tol.Distance
+		}
+		if tol.Time > 0 {
+			score += float64(abs64(pref.Time-r.eq.Time)) / float64(tol.Time)
+		}
+		if best == nil || score < bestScore {
+			best, bestScore = e, score
+		}
+	}
+	return best
+}
+
+func withinMagnitude(a, b *pb.Earthquake, tol Tolerances) bool {
+	diff := a.Magnitude - b.Magnitude
+	return diff <= tol.Magnitude && -diff <= tol.Magnitude
+}
+
+// mergeEvent returns a copy of the preferred earthquake of an event with ids
+// and sources of all earthquakes associated to the event
+func mergeEvent(e *event) *pb.Earthquake {
+	eq := proto.Clone(e.preferred()).(*pb.Earthquake)
+	if eq.Details == nil {
+		eq.Details = &pb.EarthquakeDetails{Id: eq.Id}
+	}
+	var ids, sources []string
+	for _, m := range e.members {
+		ids = appendUnique(ids, m.eq.Id)
+		sources = appendUnique(sources, m.agency)
+		if det := m.eq.Details; det != nil {
+			ids = appendUnique(ids, splitList(det.Ids)...)
+			sources = appendUnique(sources, splitList(det.Sources)...)
+		}
+	}
+	eq.Details.Ids = joinList(ids)
+	eq.Details.Sources = joinList(sources)
+	return eq
+}
+
+// splitList splits a comma-separated list like ",us,pr," to values
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// joinList joins values to a comma-separated list like ",us,pr,"
+func joinList(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return "," + strings.Join(values, ",") + ","
+}
+
+func appendUnique(values []string, added ...string) []string {
+	for _, value := range added {
+		exists := false
+		for _, v := range values {
+			if v == value {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func abs64(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
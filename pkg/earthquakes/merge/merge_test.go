@@ -0,0 +1,58 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package merge
+
+import (
+	"testing"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestAssociate(t *testing.T) {
+	report := func(id string, source int, time int64, lat int32,
+		magnitude float32) reported {
+
+		return reported{
+			eq: &pb.Earthquake{
+				Id:        id,
+				Time:      time,
+				Magnitude: magnitude,
+				Position:  &pb.GeoPointE7{Latitude: lat},
+			},
+			agency: []string{"a", "b", "c"}[source],
+			source: source,
+		}
+	}
+	tol := Tolerances{Time: 10, Distance: 50_000, Magnitude: 0.5}
+	events := associate([][]reported{
+		{
+			report("a1", 0, 1000, 0, 5.0),
+			report("a2", 0, 1005, 0, 5.0), // the same source, not merged
+		},
+		{
+			report("b1", 1, 1004, 0_2000000, 5.2), // nearest to a2 on time
+			report("b2", 1, 1000, 1_0000000, 5.0), // too far (about 111 km)
+			report("b3", 1, 1020, 0, 5.0),         // too late
+		},
+		{
+			report("c1", 2, 1001, 0, 5.4), // a1 within magnitude tolerance
+			report("c2", 2, 1002, 0, 4.0), // magnitude too small
+		},
+	}, tol)
+
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, mergeEvent(e).Details.Ids)
+	}
+	expected := []string{",a1,c1,", ",b2,", ",c2,", ",a2,b1,", ",b3,"}
+	if len(ids) != len(expected) {
+		t.Fatalf("invalid events %v", ids)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("invalid events %v", ids)
+			break
+		}
+	}
+}
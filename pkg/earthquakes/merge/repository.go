@@ -0,0 +1,299 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+// Package merge implements a repository merging earthquake catalogs of
+// several agencies (like USGS, EMSC and national networks) by associating
+// earthquakes reported by many agencies as the same earthquake.
+package merge
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// Repository merges earthquakes from sources. Of earthquakes associated as the
+// same earthquake, the one by a source with the highest priority is preferred,
+// and ids and sources of all of them are listed on details.
+//
+// Paging is not supported: page tokens are never returned (and are invalid on
+// queries) and a page size is applied as a limit.
+type Repository struct {
+	sources    []Source
+	tolerances Tolerances
+}
+
+var _ earthquakes.Repository = (*Repository)(nil)
+
+// NewRepository returns a repository merging earthquakes from sources. The
+// priority lists agencies from the preferred one, sources of agencies not
+// listed are preferred after them on the order given.
+func NewRepository(sources []Source, priority []string, tol Tolerances) *Repository {
+	rank := func(s Source) int {
+		for i, agency := range priority {
+			if agency == s.Agency() {
+				return i
+			}
+		}
+		return len(priority)
+	}
+	sorted := append([]Source(nil), sources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i]) < rank(sorted[j])
+	})
+	return &Repository{sources: sorted, tolerances: tol}
+}
+
+// ListEarthquakes lists merged earthquakes, the latest first (unless sorted by
+// a sort key of a query).
+func (r *Repository) ListEarthquakes(ctx context.Context, q earthquakes.Query) (
+	*pb.EarthquakeCollection, string, error) {
+
+	return r.listEarthquakes(ctx, q, nil, nil, nil)
+}
+
+// ListEarthquakesFocusPosition lists merged earthquakes, nearest to the
+// position first (within the max distance of a query if set).
+func (r *Repository) ListEarthquakesFocusPosition(ctx context.Context,
+	q earthquakes.Query, pos *pb.GeoPointE7) (
+	*pb.EarthquakeCollection, string, error) {
+
+	return r.listEarthquakes(ctx, q, pos, nil, nil)
+}
+
+// ListEarthquakesFocusBounds lists merged earthquakes inside bounds, nearest
+// to the center of bounds first.
+func (r *Repository) ListEarthquakesFocusBounds(ctx context.Context,
+	q earthquakes.Query, bounds *pb.GeoBoundsE7) (
+	*pb.EarthquakeCollection, string, error) {
+
+	center := earthquakes.BoundsCenter(bounds)
+	return r.listEarthquakes(ctx, q, center, bounds, nil)
+}
+
+// ListEarthquakesFocusArea lists merged earthquakes inside an area of
+// polygons, nearest to the center of bounds of the area first.
+func (r *Repository) ListEarthquakesFocusArea(ctx context.Context,
+	q earthquakes.Query, area *pb.GeoMultiPolygonE7) (
+	*pb.EarthquakeCollection, string, error) {
+
+	a, err := earthquakes.NewArea(area)
+	if err != nil {
+		return nil, "", err
+	}
+	center := earthquakes.BoundsCenter(a.Bounds())
+	return r.listEarthquakes(ctx, q, center, a.Bounds(), a)
+}
+
+// GetEarthquake returns an earthquake by id from the first source (on priority
+// order) having it.
+func (r *Repository) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+
+	var lastErr error
+	for _, s := range r.sources {
+		eq, err := s.GetEarthquake(ctx, id)
+		if err == nil {
+			return eq, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !errors.Is(err, earthquakes.ErrNotFound) {
+			log.Printf("error %v getting %s from %s", err, id, s.Agency())
+			lastErr = err
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, earthquakes.ErrNotFound
+}
+
+// GetEarthquakeProducts returns products for an earthquake by id from the
+// first source (on priority order) providing products and having it.
+func (r *Repository) GetEarthquakeProducts(ctx context.Context, id string) (
+	*pb.EarthquakeProducts, error) {
+
+	supported := false
+	var lastErr error
+	for _, s := range r.sources {
+		ps, ok := s.(productSource)
+		if !ok {
+			continue
+		}
+		supported = true
+		products, err := ps.GetEarthquakeProducts(ctx, id)
+		if err == nil {
+			return products, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !errors.Is(err, earthquakes.ErrNotFound) {
+			log.Printf("error %v getting products of %s from %s", err, id, s.Agency())
+			lastErr = err
+		}
+	}
+	if !supported {
+		return nil, earthquakes.ErrNotSupported
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, earthquakes.ErrNotFound
+}
+
+// WatchEarthquakes is not supported by the merging repository.
+func (r *Repository) WatchEarthquakes(q earthquakes.Query, pos *pb.GeoPointE7,
+	bounds *pb.GeoBoundsE7, done <-chan struct{}) (
+	<-chan []*pb.EarthquakeEvent, error) {
+
+	return nil, earthquakes.ErrNotSupported
+}
+
+func (r *Repository) listEarthquakes(ctx context.Context, q earthquakes.Query,
+	focus *pb.GeoPointE7, bounds *pb.GeoBoundsE7, area *earthquakes.Area) (
+	*pb.EarthquakeCollection, string, error) {
+
+	if q.PageToken != "" {
+		return nil, "", earthquakes.ErrInvalidPageToken
+	}
+
+	// list earthquakes from all sources and associate them to events
+	reports, err := r.collect(ctx, q, bounds)
+	if err != nil {
+		return nil, "", err
+	}
+	events := associate(reports, r.tolerances)
+
+	// merge events (inside bounds, an area and a depth range if any, as not
+	// all sources apply them), the latest or the nearest to focus first
+	features := make([]*pb.Earthquake, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		eq := mergeEvent(events[i])
+		pos := eq.Position
+		if (bounds == nil || earthquakes.InBounds(pos, bounds)) &&
+			(area == nil || area.Contains(pos)) &&
+			earthquakes.InDepthRange(pos, q.MinDepth, q.MaxDepth) {
+			features = append(features, eq)
+		}
+	}
+	if focus != nil {
+		// merged earthquakes are new ones, so focus is set on them directly
+		// (distances by the distance metric of a query)
+		near := features[:0]
+		for _, eq := range features {
+			eq.DistanceMeters = q.Distance(focus, eq.Position)
+			if q.MaxDistance > 0 && eq.DistanceMeters > q.MaxDistance {
+				continue // too far from focus, so skip
+			}
+			eq.BearingDegrees = geolib.BearingE7(focus.Latitude, focus.Longitude,
+				eq.Position.Latitude, eq.Position.Longitude)
+			near = append(near, eq)
+		}
+		features = near
+		if !q.HasSort() {
+			sort.SliceStable(features, func(i, j int) bool {
+				return features[i].DistanceMeters < features[j].DistanceMeters
+			})
+		}
+	}
+	if q.HasSort() {
+		// by a sort key of a query (with distances set above if focusing)
+		sort.Slice(features, func(i, j int) bool {
+			a, b := features[i], features[j]
+			return earthquakes.SortBefore(
+				earthquakes.SortValue(q.SortKey, a, a.DistanceMeters), a.Id,
+				earthquakes.SortValue(q.SortKey, b, b.DistanceMeters), b.Id,
+				q.SortDescending)
+		})
+	}
+
+	// apply a limit (or a page size) and details
+	limit := q.Limit
+	if q.PageSize > 0 && (limit == 0 || q.PageSize < limit) {
+		limit = q.PageSize
+	}
+	if limit > 0 && len(features) > limit {
+		features = features[:limit]
+	}
+	if !q.Details {
+		for _, eq := range features {
+			eq.Details = nil
+		}
+	}
+
+	// collection metadata and bounds of earthquakes merged
+	agencies := make([]string, len(r.sources))
+	for i, s := range r.sources {
+		agencies[i] = s.Agency()
+	}
+	col := &pb.EarthquakeCollection{
+		Metadata: &pb.EarthquakeMetadata{
+			GeneratedTime: time.Now().Unix(),
+			Title:         "Earthquakes merged from " + strings.Join(agencies, ", "),
+			Count:         int32(len(features)),
+		},
+		Features: features,
+		Bounds:   earthquakes.BoundsOf(features),
+	}
+	return col, "", nil
+}
+
+// collect lists earthquakes from all sources concurrently, sources failing
+// are skipped unless all of them fail (or the context is done), and sources
+// not supporting a query are skipped quietly
+func (r *Repository) collect(ctx context.Context, q earthquakes.Query,
+	bounds *pb.GeoBoundsE7) ([][]reported, error) {
+
+	reports := make([][]reported, len(r.sources))
+	errs := make([]error, len(r.sources))
+	var wg sync.WaitGroup
+	for i, s := range r.sources {
+		wg.Add(1)
+		go func(i int, s Source) {
+			defer wg.Done()
+			col, err := s.ListEarthquakes(ctx, q, bounds)
+			if err != nil {
+				if !errors.Is(err, ErrUnsupportedQuery) {
+					log.Printf("error %v listing earthquakes from %s", err, s.Agency())
+				}
+				errs[i] = err
+				return
+			}
+			for _, eq := range col.Features {
+				reports[i] = append(reports[i],
+					reported{eq: eq, agency: s.Agency(), source: i})
+			}
+		}(i, s)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	for i := range r.sources {
+		if errs[i] == nil {
+			return reports, nil
+		}
+	}
+	// all sources failed, a real failure preferred over a query not supported
+	for _, err := range errs {
+		if !errors.Is(err, ErrUnsupportedQuery) {
+			return nil, err
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return reports, nil
+}
@@ -0,0 +1,466 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// qmlEvent is an event served by a QuakeML agency stand-in
+type qmlEvent struct {
+	id        string
+	time      string
+	lat, lon  float64
+	magnitude float64
+}
+
+// newQuakeMLAgency returns a test server standing in for a FDSN event web
+// service of an agency serving events as QuakeML
+func newQuakeMLAgency(agency string, events ...qmlEvent) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "xml" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var b strings.Builder
+		b.WriteString(`<q:quakeml xmlns="http://quakeml.org/xmlns/bed/1.2" xmlns:q="http://quakeml.org/xmlns/quakeml/1.2"><eventParameters publicID="smi:test/query">`)
+		for _, e := range events {
+			if id := r.URL.Query().Get("eventid"); id != "" && id != e.id {
+				continue
+			}
+			fmt.Fprintf(&b, `<event publicID="smi:test/event/%s"><creationInfo><agencyID>%s</agencyID></creationInfo>`, e.id, agency)
+			fmt.Fprintf(&b, `<origin publicID="o"><time><value>%s</value></time><latitude><value>%v</value></latitude><longitude><value>%v</value></longitude><depth><value>10000</value></depth></origin>`, e.time, e.lat, e.lon)
+			fmt.Fprintf(&b, `<magnitude publicID="m"><mag><value>%v</value></mag><type>mb</type></magnitude></event>`, e.magnitude)
+		}
+		b.WriteString(`</eventParameters></q:quakeml>`)
+		w.Write([]byte(b.String()))
+	}))
+}
+
+// newGeoJSONAgency returns a test server standing in for the FDSN event web
+// service of the USGS serving earthquakes as GeoJSON
+func newGeoJSONAgency(features ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "geojson" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// like the USGS, an event is served as a single feature
+		if id := r.URL.Query().Get("eventid"); id != "" {
+			for _, f := range features {
+				if strings.Contains(f, `"id":"`+id+`"`) {
+					w.Write([]byte(f))
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"type":"FeatureCollection","features":[%s]}`,
+			strings.Join(features, ","))
+	}))
+}
+
+func geoJSONFeature(id string, time int64, lat, lon float64, mag float64,
+	ids, sources string) string {
+
+	return fmt.Sprintf(`{"type":"Feature","id":"%s","properties":{"mag":%v,"time":%d,"ids":"%s","sources":"%s","net":"us"},"geometry":{"type":"Point","coordinates":[%v,%v,10]}}`,
+		id, mag, time*1000, ids, sources, lon, lat)
+}
+
+func newTestRepository(priority ...string) (*Repository, func()) {
+	// USGS and EMSC report the same earthquake (in Puerto Rico) and a smaller
+	// one (in Crete) is reported only by EMSC, USGS reports one in Alaska
+	usgs := newGeoJSONAgency(
+		geoJSONFeature("us70006vll", 1578385466, 17.9157, -66.8113, 6.4,
+			",pr2020007000,us70006vll,", ",pr,us,"),
+		geoJSONFeature("ak020122", 1578389000, 61.2, -150.1, 3.1,
+			",ak020122,", ",ak,"),
+	)
+	emsc := newQuakeMLAgency("EMSC",
+		qmlEvent{"20200107_0000030", "2020-01-07T08:24:27.0Z", 17.86, -66.82, 6.5},
+		qmlEvent{"20200107_0000054", "2020-01-07T10:02:11.3Z", 35.30, 25.10, 2.1},
+	)
+	r := NewRepository([]Source{
+		NewFDSNSource("EMSC", emsc.URL, FormatQuakeML, nil),
+		NewFDSNSource("us", usgs.URL, FormatGeoJSON, nil),
+	}, priority, DefaultTolerances)
+	return r, func() {
+		usgs.Close()
+		emsc.Close()
+	}
+}
+
+func TestMergeAgencies(t *testing.T) {
+	ctx := context.Background()
+	r, close := newTestRepository("us", "EMSC")
+	defer close()
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+		Past:      pb.Past_PAST_30DAYS,
+		StartTime: 1578000000,
+		Details:   true,
+	}
+	col, next, err := r.ListEarthquakes(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != "" {
+		t.Error("next page token not expected")
+	}
+
+	// duplicates are associated, the latest earthquake is first
+	if len(col.Features) != 3 || col.Metadata.Count != 3 {
+		t.Fatalf("invalid feature count %d", len(col.Features))
+	}
+	if col.Features[0].Id != "20200107_0000054" || col.Features[1].Id != "ak020122" {
+		t.Error("invalid order")
+	}
+
+	// USGS has priority, ids and sources are merged
+	eq := col.Features[2]
+	if eq.Id != "us70006vll" || eq.Magnitude != 6.4 {
+		t.Errorf("invalid preferred earthquake %s", eq.Id)
+	}
+	if eq.Details.Ids != ",us70006vll,pr2020007000,20200107_0000030," {
+		t.Errorf("invalid ids %s", eq.Details.Ids)
+	}
+	if eq.Details.Sources != ",us,pr,EMSC," {
+		t.Errorf("invalid sources %s", eq.Details.Sources)
+	}
+	if col.Features[0].Details.Sources != ",EMSC," {
+		t.Error("invalid sources of an earthquake not merged")
+	}
+
+	// EMSC has priority
+	r, close = newTestRepository("EMSC", "us")
+	defer close()
+	col, _, err = r.ListEarthquakes(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eq = col.Features[2]
+	if eq.Id != "20200107_0000030" || eq.Magnitude != 6.5 {
+		t.Errorf("invalid preferred earthquake %s", eq.Id)
+	}
+	if eq.Details.Ids != ",20200107_0000030,us70006vll,pr2020007000," ||
+		eq.Details.Sources != ",EMSC,us,pr," {
+		t.Error("invalid ids or sources")
+	}
+
+	// nearest first with a limit and without details
+	q.Details = false
+	q.Limit = 2
+	col, _, err = r.ListEarthquakesFocusPosition(ctx, q,
+		&pb.GeoPointE7{Latitude: 60_0000000, Longitude: -150_0000000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 2 || col.Features[0].Id != "ak020122" ||
+		col.Features[1].Id != "20200107_0000030" || col.Features[0].Details != nil {
+		t.Error("invalid earthquakes nearest first")
+	}
+
+	// page tokens are not valid
+	q.PageToken = "token"
+	if _, _, err := r.ListEarthquakes(ctx, q); err != earthquakes.ErrInvalidPageToken {
+		t.Error("expected ErrInvalidPageToken")
+	}
+}
+
+func TestMergeSort(t *testing.T) {
+	ctx := context.Background()
+	r, close := newTestRepository("us", "EMSC")
+	defer close()
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+		Past:      pb.Past_PAST_30DAYS,
+		StartTime: 1578000000,
+		SortKey:   pb.SortKey_SORT_KEY_MAGNITUDE,
+	}
+	for _, test := range []struct {
+		descending bool
+		want       string
+	}{
+		{false, "[20200107_0000054 ak020122 us70006vll]"},
+		{true, "[us70006vll ak020122 20200107_0000054]"},
+	} {
+		q.SortDescending = test.descending
+		col, _, err := r.ListEarthquakes(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]string, len(col.Features))
+		for i, eq := range col.Features {
+			ids[i] = eq.Id
+		}
+		if fmt.Sprint(ids) != test.want {
+			t.Errorf("got %v by magnitude, want %s", ids, test.want)
+		}
+	}
+
+	// the farthest first from a focus with a limit
+	q.SortKey = pb.SortKey_SORT_KEY_DISTANCE
+	q.Limit = 1
+	col, _, err := r.ListEarthquakesFocusPosition(ctx, q,
+		&pb.GeoPointE7{Latitude: 60_0000000, Longitude: -150_0000000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 1 || col.Features[0].Id != "20200107_0000054" {
+		t.Errorf("invalid farthest earthquake %v", col.Features)
+	}
+}
+
+func TestMergeGetEarthquake(t *testing.T) {
+	ctx := context.Background()
+	r, close := newTestRepository("us", "EMSC")
+	defer close()
+	eq, err := r.GetEarthquake(ctx, "20200107_0000054")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq.Id != "20200107_0000054" {
+		t.Error("invalid earthquake")
+	}
+	eq, err = r.GetEarthquake(ctx, "us70006vll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eq.Id != "us70006vll" || eq.Details.Sources != ",pr,us," {
+		t.Error("invalid earthquake")
+	}
+	if _, err := r.GetEarthquake(ctx, "unknown"); err != earthquakes.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := r.GetEarthquakeProducts(ctx, "20200107_0000054"); err != earthquakes.ErrNotSupported {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+// failingSource is a source failing all requests with an error
+type failingSource struct {
+	agency string
+	err    error
+}
+
+func (s *failingSource) Agency() string {
+	return s.agency
+}
+
+func (s *failingSource) ListEarthquakes(ctx context.Context,
+	q earthquakes.Query, bounds *pb.GeoBoundsE7) (
+	*pb.EarthquakeCollection, error) {
+	return nil, s.err
+}
+
+func (s *failingSource) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+	return nil, s.err
+}
+
+func TestMergeSkippedSources(t *testing.T) {
+	ctx := context.Background()
+	unavailable := &earthquakes.UpstreamError{
+		URL:    "http://localhost/query",
+		Reason: earthquakes.ReasonUnavailable,
+	}
+
+	// wrapped not found errors are not failures
+	r := NewRepository([]Source{
+		&failingSource{"us", fmt.Errorf("us: %w", earthquakes.ErrNotFound)},
+	}, nil, DefaultTolerances)
+	if _, err := r.GetEarthquake(ctx, "unknown"); err != earthquakes.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	// sources not supporting a query are skipped, other failures reported
+	r = NewRepository([]Source{
+		&failingSource{"EMSC", ErrUnsupportedQuery},
+		&failingSource{"us", unavailable},
+	}, nil, DefaultTolerances)
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_SIGNIFICANT,
+		Past:      pb.Past_PAST_DAY,
+	}
+	if _, _, err := r.ListEarthquakes(ctx, q); err != unavailable {
+		t.Errorf("expected an upstream error, got %v", err)
+	}
+}
+
+func TestMergeFailingAgency(t *testing.T) {
+	ctx := context.Background()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	emsc := newQuakeMLAgency("EMSC",
+		qmlEvent{"20200107_0000054", "2020-01-07T10:02:11.3Z", 35.30, 25.10, 2.1})
+	defer emsc.Close()
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+		Past:      pb.Past_PAST_DAY,
+	}
+
+	// earthquakes from other agencies are listed if one fails
+	r := NewRepository([]Source{
+		NewFDSNSource("us", failing.URL, FormatGeoJSON, nil),
+		NewFDSNSource("EMSC", emsc.URL, FormatQuakeML, nil),
+	}, nil, DefaultTolerances)
+	col, _, err := r.ListEarthquakes(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 1 {
+		t.Error("invalid feature count")
+	}
+
+	// an error if all agencies fail
+	r = NewRepository([]Source{
+		NewFDSNSource("us", failing.URL, FormatGeoJSON, nil),
+	}, nil, DefaultTolerances)
+	if _, _, err := r.ListEarthquakes(ctx, q); err == nil {
+		t.Error("expected an error")
+	}
+
+	// significance is not supported by FDSN sources
+	q.Magnitude = pb.Magnitude_MAGNITUDE_SIGNIFICANT
+	if _, err := NewFDSNSource("EMSC", emsc.URL, FormatQuakeML, nil).
+		ListEarthquakes(ctx, q, nil); err != ErrUnsupportedQuery {
+		t.Error("expected ErrUnsupportedQuery")
+	}
+	// a client given to a source is used for fetching (timing out here)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+	q.Magnitude = pb.Magnitude_MAGNITUDE_ALL
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, err = NewFDSNSource("us", slow.URL, FormatGeoJSON, client).
+		ListEarthquakes(ctx, q, nil)
+	var uerr *earthquakes.UpstreamError
+	if !errors.As(err, &uerr) || uerr.Reason != earthquakes.ReasonUnavailable {
+		t.Errorf("expected an upstream error, got %v", err)
+	}
+}
+
+func TestMergeAntimeridian(t *testing.T) {
+	ctx := context.Background()
+	usgs := newGeoJSONAgency(
+		geoJSONFeature("us1", 1578385466, -17.9, 179.5, 5.1, ",us1,", ",us,"),
+		geoJSONFeature("us2", 1578385000, -18.2, -179.2, 4.8, ",us2,", ",us,"),
+		geoJSONFeature("us3", 1578384000, -18.0, 10.0, 4.6, ",us3,", ",us,"),
+	)
+	defer usgs.Close()
+	r := NewRepository([]Source{
+		NewFDSNSource("us", usgs.URL, FormatGeoJSON, nil),
+	}, nil, DefaultTolerances)
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+		Past:      pb.Past_PAST_30DAYS,
+		StartTime: 1578000000,
+	}
+
+	// bounds from 170° to -170° cross the antimeridian, so filtered locally
+	col, _, err := r.ListEarthquakesFocusBounds(ctx, q, &pb.GeoBoundsE7{
+		MinLatitude:  -20_0000000,
+		MinLongitude: 170_0000000,
+		MaxLatitude:  -10_0000000,
+		MaxLongitude: -170_0000000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 2 {
+		t.Fatalf("got %d features inside bounds, want 2", len(col.Features))
+	}
+	if b := col.Bounds; b.MinLongitude != 179_5000000 || b.MaxLongitude != -179_2000000 {
+		t.Errorf("unexpected bounds %v", b)
+	}
+
+	// the max distance cuts off earthquakes far from a focus position (with
+	// a distance and a bearing set on those nearer)
+	q.MaxDistance = 100_000
+	col, _, err = r.ListEarthquakesFocusPosition(ctx, q,
+		&pb.GeoPointE7{Latitude: -18_0000000, Longitude: 180_0000000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 2 || col.Features[0].Id != "us1" {
+		t.Fatalf("invalid features within 100 km: %v", col.Features)
+	}
+	for _, eq := range col.Features {
+		if eq.DistanceMeters <= 0 || eq.DistanceMeters > q.MaxDistance ||
+			eq.BearingDegrees < 0 || eq.BearingDegrees >= 360 {
+			t.Errorf("invalid distance or bearing on %s", eq.Id)
+		}
+	}
+}
+
+func TestMergeDepth(t *testing.T) {
+	ctx := context.Background()
+	usgs := newGeoJSONAgency(
+		geoJSONFeature("us1", 1578385466, -17.9, 179.5, 5.1, ",us1,", ",us,"),
+		geoJSONFeature("us2", 1578385000, -18.2, -179.2, 4.8, ",us2,", ",us,"),
+	)
+	defer usgs.Close()
+	r := NewRepository([]Source{
+		NewFDSNSource("us", usgs.URL, FormatGeoJSON, nil),
+	}, nil, DefaultTolerances)
+
+	// earthquakes at 10 km are filtered locally by depth ranges (the test
+	// agency ignores depth parameters)
+	for _, test := range []struct {
+		min, max float32
+		want     int
+	}{
+		{5, 15, 2},
+		{20, 30, 0},
+	} {
+		q := earthquakes.Query{
+			Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+			Past:      pb.Past_PAST_30DAYS,
+			StartTime: 1578000000,
+			MinDepth:  &test.min,
+			MaxDepth:  &test.max,
+		}
+		col, _, err := r.ListEarthquakes(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(col.Features) != test.want {
+			t.Errorf("got %d features between %v and %v km, want %d",
+				len(col.Features), test.min, test.max, test.want)
+		}
+	}
+
+	// hypocentral distances from a focus on the surface
+	q := earthquakes.Query{
+		Magnitude:      pb.Magnitude_MAGNITUDE_ALL,
+		Past:           pb.Past_PAST_30DAYS,
+		StartTime:      1578000000,
+		DistanceMetric: pb.DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL,
+	}
+	focus := &pb.GeoPointE7{Latitude: -17_9000000, Longitude: 179_5000000}
+	col, _, err := r.ListEarthquakesFocusPosition(ctx, q, focus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 2 || col.Features[0].Id != "us1" {
+		t.Fatalf("invalid features: %v", col.Features)
+	}
+	if d := col.Features[0].DistanceMeters; d < 9_999 || d > 10_001 {
+		t.Errorf("invalid hypocentral distance %f to a hypocenter at 10 km", d)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
+	"github.com/navibyte/quake/pkg/earthquakes/usgs"
+	"github.com/tidwall/gjson"
+)
+
+// ErrUnsupportedQuery is returned by a source that cannot serve a query
+var ErrUnsupportedQuery error = &earthquakes.ValidationError{
+	Message: "query not supported by the source",
+}
+
+// Source provides earthquakes reported by an agency.
+type Source interface {
+	// Agency returns a name of the agency (like "us" or "EMSC").
+	Agency() string
+
+	// ListEarthquakes lists earthquakes (with details) for a query and
+	// optional bounds. Paging and limits of a query are not applied.
+	ListEarthquakes(ctx context.Context, q earthquakes.Query,
+		bounds *pb.GeoBoundsE7) (*pb.EarthquakeCollection, error)
+
+	// GetEarthquake returns an earthquake by id or earthquakes.ErrNotFound.
+	GetEarthquake(ctx context.Context, id string) (*pb.Earthquake, error)
+}
+
+// productSource is implemented by sources also providing products
+type productSource interface {
+	GetEarthquakeProducts(ctx context.Context, id string) (
+		*pb.EarthquakeProducts, error)
+}
+
+// NewRepositorySource returns a source for an agency backed by a repository.
+func NewRepositorySource(agency string, repo earthquakes.Repository) Source {
+	return &repositorySource{agency: agency, repo: repo}
+}
+
+type repositorySource struct {
+	agency string
+	repo   earthquakes.Repository
+}
+
+func (s *repositorySource) Agency() string {
+	return s.agency
+}
+
+func (s *repositorySource) ListEarthquakes(ctx context.Context,
+	q earthquakes.Query, bounds *pb.GeoBoundsE7) (
+	*pb.EarthquakeCollection, error) {
+
+	// all earthquakes with details are needed for merging
+	q.Details = true
+	q.Limit = 0
+	q.PageSize = 0
+	q.PageToken = ""
+	var col *pb.EarthquakeCollection
+	var err error
+	if bounds != nil {
+		col, _, err = s.repo.ListEarthquakesFocusBounds(ctx, q, bounds)
+	} else {
+		col, _, err = s.repo.ListEarthquakes(ctx, q)
+	}
+	return col, err
+}
+
+func (s *repositorySource) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+
+	return s.repo.GetEarthquake(ctx, id)
+}
+
+func (s *repositorySource) GetEarthquakeProducts(ctx context.Context,
+	id string) (*pb.EarthquakeProducts, error) {
+
+	return s.repo.GetEarthquakeProducts(ctx, id)
+}
+
+// Format is a data format of a FDSN event web service.
+type Format int
+
+const (
+	// FormatQuakeML is QuakeML 1.2 ("format=xml").
+	FormatQuakeML Format = iota
+
+	// FormatGeoJSON is GeoJSON of the USGS ("format=geojson").
+	FormatGeoJSON
+)
+
+// defaultFDSNTimeout is a timeout for a client used to fetch from FDSN event
+// web services by default
+const defaultFDSNTimeout = 10 * time.Second
+
+// NewFDSNSource returns a source for an agency querying earthquakes from a
+// FDSN event web service (like
+// "https://www.seismicportal.eu/fdsnws/event/1/query") using a HTTP client
+// given (or a client with a default timeout if nil).
+func NewFDSNSource(agency string, queryURL string, format Format,
+	client *http.Client) Source {
+
+	if client == nil {
+		client = &http.Client{Timeout: defaultFDSNTimeout}
+	}
+	return &fdsnSource{
+		agency: agency,
+		url:    queryURL,
+		format: format,
+		client: client,
+	}
+}
+
+type fdsnSource struct {
+	agency string
+	url    string
+	format Format
+	client *http.Client
+}
+
+func (s *fdsnSource) Agency() string {
+	return s.agency
+}
+
+func (s *fdsnSource) ListEarthquakes(ctx context.Context, q earthquakes.Query,
+	bounds *pb.GeoBoundsE7) (*pb.EarthquakeCollection, error) {
+
+	const timeFormat = "2006-01-02T15:04:05"
+	params := url.Values{}
+	params.Set("orderby", "time")
+	start, end := q.Window(time.Now())
+	params.Set("starttime", time.Unix(start, 0).UTC().Format(timeFormat))
+	if end != 0 {
+		params.Set("endtime", time.Unix(end, 0).UTC().Format(timeFormat))
+	}
+
+	// minimum of the magnitude range overrides the magnitude filter
+	if q.MinMagnitude != nil {
+		params.Set("minmagnitude", formatFloat(float64(*q.MinMagnitude)))
+	} else {
+		switch q.Magnitude {
+		case pb.Magnitude_MAGNITUDE_M45_PLUS:
+			params.Set("minmagnitude", "4.5")
+		case pb.Magnitude_MAGNITUDE_M25_PLUS:
+			params.Set("minmagnitude", "2.5")
+		case pb.Magnitude_MAGNITUDE_M10_PLUS:
+			params.Set("minmagnitude", "1.0")
+		case pb.Magnitude_MAGNITUDE_ALL:
+		default:
+			// significance is not defined by the FDSN specification
+			return nil, ErrUnsupportedQuery
+		}
+	}
+	if q.MaxMagnitude != nil {
+		params.Set("maxmagnitude", formatFloat(float64(*q.MaxMagnitude)))
+	}
+	if q.MinDepth != nil {
+		params.Set("mindepth", formatFloat(float64(*q.MinDepth)))
+	}
+	if q.MaxDepth != nil {
+		params.Set("maxdepth", formatFloat(float64(*q.MaxDepth)))
+	}
+	// bounds crossing the antimeridian are not supported by all services, so
+	// longitudes of such bounds are filtered after parsing
+	crossing := bounds != nil && bounds.MinLongitude > bounds.MaxLongitude
+	if bounds != nil {
+		params.Set("minlatitude", formatFloat(geolib.LatFromE7(bounds.MinLatitude)))
+		params.Set("maxlatitude", formatFloat(geolib.LatFromE7(bounds.MaxLatitude)))
+		if !crossing {
+			params.Set("minlongitude", formatFloat(geolib.LonFromE7(bounds.MinLongitude)))
+			params.Set("maxlongitude", formatFloat(geolib.LonFromE7(bounds.MaxLongitude)))
+		}
+	}
+
+	data, err := s.fetch(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		// no earthquakes found
+		return &pb.EarthquakeCollection{}, nil
+	}
+	col, err := s.parse(data)
+	if err != nil || !crossing {
+		return col, err
+	}
+	features := col.Features[:0]
+	for _, eq := range col.Features {
+		if earthquakes.InBounds(eq.Position, bounds) {
+			features = append(features, eq)
+		}
+	}
+	col.Features = features
+	col.Bounds = earthquakes.BoundsOf(features)
+	return col, nil
+}
+
+func (s *fdsnSource) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+
+	params := url.Values{}
+	params.Set("eventid", id)
+	data, err := s.fetch(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, earthquakes.ErrNotFound
+	}
+	if s.format == FormatGeoJSON && gjson.GetBytes(data, "type").String() == "Feature" {
+		// the USGS responds with a single feature (the detail format)
+		data = []byte(`{"type":"FeatureCollection","features":[` + string(data) + `]}`)
+	}
+	col, err := s.parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(col.Features) == 0 {
+		return nil, earthquakes.ErrNotFound
+	}
+	return col.Features[0], nil
+}
+
+// fetch calls the web service, returns no data if nothing was found
+func (s *fdsnSource) fetch(ctx context.Context, params url.Values) (
+	[]byte, error) {
+
+	switch s.format {
+	case FormatGeoJSON:
+		params.Set("format", "geojson")
+	default:
+		params.Set("format", "xml")
+	}
+	request, err := http.NewRequest("GET", s.url+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(request.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, s.upstreamError(earthquakes.ReasonUnavailable, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, s.upstreamError(earthquakes.ReasonUnavailable, err)
+		}
+		return data, nil
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, s.upstreamError(earthquakes.ReasonUnavailable,
+			fmt.Errorf("%s responded with %d", s.agency, resp.StatusCode))
+	}
+}
+
+func (s *fdsnSource) parse(data []byte) (*pb.EarthquakeCollection, error) {
+	var col *pb.EarthquakeCollection
+	var err error
+	if s.format == FormatGeoJSON {
+		col, err = usgs.ToEarthquakeCollection(data, true)
+	} else {
+		col, err = usgs.ToEarthquakeCollectionFromQuakeML(data, true)
+	}
+	if err != nil {
+		return nil, s.upstreamError(earthquakes.ReasonInvalidData, err)
+	}
+	return col, nil
+}
+
+// upstreamError returns an error for the web service with a reason
+func (s *fdsnSource) upstreamError(reason string, err error) error {
+	return &earthquakes.UpstreamError{URL: s.url, Reason: reason, Err: err}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
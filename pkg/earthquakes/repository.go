@@ -0,0 +1,195 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+// Package earthquakes defines abstractions for earthquake data repositories
+// that are implemented by catalog specific packages (like usgs).
+package earthquakes
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+)
+
+// ErrNotFound is returned when identified earthquake was not found
+var ErrNotFound = errors.New("earthquake not found")
+
+// ErrInvalidPageToken is returned when a page token is not valid for a query
+var ErrInvalidPageToken error = &ValidationError{
+	Message: "invalid page token",
+	Violations: []FieldViolation{
+		{Field: "page_token", Description: "not valid for the query"},
+	},
+}
+
+// ErrNotSupported is returned when a repository does not support an operation
+var ErrNotSupported = errors.New("operation not supported")
+
+// Query contains parameters for listing earthquakes from a repository.
+type Query struct {
+	// Magnitude sets the minimum magnitude for filtering earthquakes.
+	Magnitude pb.Magnitude
+
+	// Past is a period (like past day) filter.
+	Past pb.Past
+
+	// Limit is a maximum number of earthquakes to return. If 0 no limit apply.
+	Limit int
+
+	// Details, if true, tells to return earthquakes with detailed data.
+	Details bool
+
+	// StartTime and EndTime (UTC seconds since Unix epoch) define an optional
+	// time window. If only EndTime is set the window starts at the start of
+	// the past period. If only StartTime is set the window is open ended.
+	StartTime int64
+	EndTime   int64
+
+	// MinMagnitude and MaxMagnitude define an optional magnitude range. When
+	// MinMagnitude is set it's used instead of Magnitude.
+	MinMagnitude *float32
+	MaxMagnitude *float32
+
+	// PageSize is a maximum number of earthquakes on a page and PageToken
+	// is a token from a previous page. Limit is not applied when paging.
+	PageSize  int
+	PageToken string
+
+	// MaxDistance is an optional cutoff (meters) for listing with a focus
+	// position. If 0 no cutoff apply.
+	MaxDistance float64
+
+	// MinDepth and MaxDepth define an optional depth range (kilometers below
+	// sea level).
+	MinDepth *float32
+	MaxDepth *float32
+
+	// DistanceMetric is a metric for distances from a focus (surface
+	// distances if unspecified).
+	DistanceMetric pb.DistanceMetric
+
+	// SortKey is an optional property to sort earthquakes by (ascending
+	// unless SortDescending is true), ties are broken by ids.
+	SortKey        pb.SortKey
+	SortDescending bool
+}
+
+// IsPaging returns true if the query asks for a page of earthquakes.
+func (q Query) IsPaging() bool {
+	return q.PageSize > 0 || q.PageToken != ""
+}
+
+// HasWindow returns true if the query has a time window.
+func (q Query) HasWindow() bool {
+	return q.StartTime != 0 || q.EndTime != 0
+}
+
+// Window returns start and end time for a query (end as 0 if open ended). An
+// open start is resolved from the past period before now.
+func (q Query) Window(now time.Time) (int64, int64) {
+	start := q.StartTime
+	if start == 0 {
+		start = now.Add(-PastPeriod(q.Past)).Unix()
+	}
+	return start, q.EndTime
+}
+
+// HasMagnitudeRange returns true if the query has a magnitude range.
+func (q Query) HasMagnitudeRange() bool {
+	return q.MinMagnitude != nil || q.MaxMagnitude != nil
+}
+
+// HasDepthRange returns true if the query has a depth range.
+func (q Query) HasDepthRange() bool {
+	return q.MinDepth != nil || q.MaxDepth != nil
+}
+
+// HasSort returns true if the query has a sort key.
+func (q Query) HasSort() bool {
+	return q.SortKey != pb.SortKey_SORT_KEY_UNSPECIFIED
+}
+
+// IsHypocentral returns true if the query measures distances from a focus to
+// hypocenters (instead of distances on the surface).
+func (q Query) IsHypocentral() bool {
+	return q.DistanceMetric == pb.DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL
+}
+
+// Distance returns a distance (meters) from a focus to a position by the
+// distance metric of the query.
+func (q Query) Distance(focus, pos *pb.GeoPointE7) float64 {
+	if q.IsHypocentral() {
+		return geolib.HypocentralDistanceE7(
+			focus.Latitude, focus.Longitude, focus.Height,
+			pos.Latitude, pos.Longitude, pos.Height)
+	}
+	return geolib.DistanceE7(focus.Latitude, focus.Longitude,
+		pos.Latitude, pos.Longitude)
+}
+
+// PastPeriod returns a time period covered by the past.
+func PastPeriod(past pb.Past) time.Duration {
+	switch past {
+	case pb.Past_PAST_HOUR:
+		return time.Hour
+	case pb.Past_PAST_DAY:
+		return 24 * time.Hour
+	case pb.Past_PAST_7DAYS:
+		return 7 * 24 * time.Hour
+	default:
+		return 30 * 24 * time.Hour
+	}
+}
+
+// Repository provides access to earthquakes of some earthquake catalog.
+//
+// List methods return a collection and a token for the next page (empty if
+// not paging or no more earthquakes). Earthquakes are listed on orders
+// described below unless a query has a sort key.
+//
+// Methods taking a context return an error of the context (like
+// context.Canceled or context.DeadlineExceeded) if it's done before data is
+// available.
+type Repository interface {
+	// ListEarthquakes lists earthquakes on a order they are provided by a
+	// catalog.
+	ListEarthquakes(ctx context.Context, q Query) (
+		*pb.EarthquakeCollection, string, error)
+
+	// ListEarthquakesFocusPosition lists earthquakes nearest to the position
+	// coming first on the list (only those within the max distance of a
+	// query if set). Earthquakes listed with a focus have a distance and a
+	// bearing from the position (or the center of bounds) set.
+	ListEarthquakesFocusPosition(ctx context.Context, q Query,
+		pos *pb.GeoPointE7) (*pb.EarthquakeCollection, string, error)
+
+	// ListEarthquakesFocusBounds lists earthquakes inside bounds (earthquakes
+	// nearest to the center of bounds coming first on the list).
+	ListEarthquakesFocusBounds(ctx context.Context, q Query,
+		bounds *pb.GeoBoundsE7) (*pb.EarthquakeCollection, string, error)
+
+	// ListEarthquakesFocusArea lists earthquakes inside an area of polygons
+	// (earthquakes nearest to the center of bounds of the area coming first
+	// on the list).
+	ListEarthquakesFocusArea(ctx context.Context, q Query,
+		area *pb.GeoMultiPolygonE7) (*pb.EarthquakeCollection, string, error)
+
+	// GetEarthquake returns an earthquake by id or ErrNotFound if not found.
+	GetEarthquake(ctx context.Context, id string) (*pb.Earthquake, error)
+
+	// GetEarthquakeProducts returns products (like origin, moment tensor or
+	// ShakeMap summaries) for an earthquake by id or ErrNotFound if not found.
+	GetEarthquakeProducts(ctx context.Context, id string) (
+		*pb.EarthquakeProducts, error)
+
+	// WatchEarthquakes streams batches of events for earthquakes added,
+	// updated or deleted. The first batch contains earthquakes currently
+	// available as added events. Optional pos (sorting events) or bounds
+	// (filtering events) focus events like on list methods. The channel
+	// returned is closed after done is closed or if watching fails.
+	WatchEarthquakes(q Query, pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7,
+		done <-chan struct{}) (<-chan []*pb.EarthquakeEvent, error)
+}
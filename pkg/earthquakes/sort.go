@@ -0,0 +1,60 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package earthquakes
+
+import (
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+// SortValue returns a value of an earthquake for a sort key, a distance from
+// a focus (meters) is given for SORT_KEY_DISTANCE.
+func SortValue(key pb.SortKey, eq *pb.Earthquake, distance float64) float64 {
+	switch key {
+	case pb.SortKey_SORT_KEY_TIME:
+		return float64(eq.Time)
+	case pb.SortKey_SORT_KEY_UPDATED_TIME:
+		return float64(eq.UpdatedTime)
+	case pb.SortKey_SORT_KEY_MAGNITUDE:
+		return float64(eq.Magnitude)
+	case pb.SortKey_SORT_KEY_SIGNIFICANCE:
+		return float64(eq.Significance)
+	case pb.SortKey_SORT_KEY_ALERT:
+		return float64(AlertLevel(eq.Alert))
+	case pb.SortKey_SORT_KEY_DISTANCE:
+		return distance
+	default:
+		return 0
+	}
+}
+
+// AlertLevel returns a level of an alert from 0 (no alert) and 1 (green) to
+// 4 (red).
+func AlertLevel(alert pb.Alert) int {
+	switch alert {
+	case pb.Alert_ALERT_GREEN:
+		return 1
+	case pb.Alert_ALERT_YELLOW:
+		return 2
+	case pb.Alert_ALERT_ORANGE:
+		return 3
+	case pb.Alert_ALERT_RED:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// SortBefore returns true if an earthquake with a sort value comes before
+// other one on a list sorted (ascending or descending), ties broken by ids.
+func SortBefore(value float64, id string, otherValue float64, otherID string,
+	descending bool) bool {
+
+	if value != otherValue {
+		if descending {
+			return value > otherValue
+		}
+		return value < otherValue
+	}
+	return id < otherID
+}
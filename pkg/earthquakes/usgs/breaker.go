@@ -0,0 +1,225 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerTimeout   = 30 * time.Second
+	defaultBackoffBase      = 250 * time.Millisecond
+	defaultBackoffMax       = 5 * time.Second
+)
+
+// ErrCircuitOpen is returned when fetching is rejected by an open circuit
+// breaker of an upstream host
+var ErrCircuitOpen = errors.New("circuit breaker open for upstream host")
+
+// breakerState is a state of a circuit breaker
+type breakerState int
+
+const (
+	// breakerClosed lets all requests through
+	breakerClosed breakerState = iota
+
+	// breakerOpen rejects all requests until a timeout is elapsed
+	breakerOpen
+
+	// breakerHalfOpen lets one probe request through, that closes the
+	// breaker if succeeded or opens it again if failed
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// breakerStat contains statistics about a circuit breaker
+type breakerStat struct {
+	state       breakerState
+	openCount   int // times opened
+	probeCount  int // probes let through when half-open
+	rejectCount int // requests rejected when open
+}
+
+// breaker is a circuit breaker for an upstream host shared by all entries of
+// a cache, opened after consecutive failures
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	timeout   time.Duration
+	failures  int       // consecutive failures
+	openUntil time.Time // time to move from open to half-open
+	probing   bool      // a probe in flight when half-open
+	breakerStat
+}
+
+// allow returns nil if a request is allowed, or ErrCircuitOpen if it is
+// rejected (a request allowed must be followed by success or failure)
+func (b *breaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerOpen && !now.Before(b.openUntil) {
+		b.state = breakerHalfOpen
+	}
+	switch b.state {
+	case breakerOpen:
+		b.rejectCount++
+		return ErrCircuitOpen
+	case breakerHalfOpen:
+		if b.probing {
+			b.rejectCount++
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		b.probeCount++
+	}
+	return nil
+}
+
+// success records a request succeeded, closing the breaker
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	b.state = breakerClosed
+}
+
+// failure records a request failed, opening the breaker if a probe failed or
+// too many consecutive requests failed
+func (b *breaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openUntil = now.Add(b.timeout)
+		b.openCount++
+	}
+	b.probing = false
+}
+
+// release records a request allowed was not finished (like when canceled),
+// letting another probe through if half-open
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// openError returns an error for a request to an URL rejected by a breaker,
+// with a time that the breaker lets a probe through
+func (b *breaker) openError(url string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &earthquakes.UpstreamError{
+		URL:     url,
+		Reason:  earthquakes.ReasonCircuitOpen,
+		RetryAt: b.openUntil,
+		Err:     ErrCircuitOpen,
+	}
+}
+
+// stat returns latest statistics about a breaker
+func (b *breaker) stat() breakerStat {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.breakerStat
+}
+
+// breaker returns a circuit breaker for a host of an URL (created on demand)
+func (c *Cache) breaker(rawurl string) *breaker {
+	host := rawurl
+	if u, err := url.Parse(rawurl); err == nil {
+		host = u.Host
+	}
+	c.breakerMutex.Lock()
+	defer c.breakerMutex.Unlock()
+	b, ok := c.breakers[host]
+	if !ok {
+		b = &breaker{threshold: c.breakerThreshold, timeout: c.breakerTimeout}
+		c.breakers[host] = b
+	}
+	return b
+}
+
+// fetchThroughBreaker fetches data from an URL through a breaker for a host
+// of the URL (rejected without fetching if the breaker is open)
+func (c *Cache) fetchThroughBreaker(ctx context.Context, url string,
+	prev resource) (*response, error) {
+
+	b := c.breaker(url)
+	if err := b.allow(time.Now()); err != nil {
+		return nil, b.openError(url)
+	}
+	resp, err := c.fetchFromURL(ctx, url, prev)
+	switch {
+	case err == nil:
+		b.success()
+	case ctx.Err() != nil:
+		b.release()
+	default:
+		b.failure(time.Now())
+	}
+	return resp, err
+}
+
+// getBreakerStat returns latest statistics about a breaker for a host
+func (c *Cache) getBreakerStat(host string) breakerStat {
+	c.breakerMutex.Lock()
+	b, ok := c.breakers[host]
+	c.breakerMutex.Unlock()
+	if !ok {
+		return breakerStat{}
+	}
+	return b.stat()
+}
+
+// backoff returns a delay before a retry (round starting from 1), growing
+// exponentially from the base up to the max, with "equal jitter" (a random
+// delay between a half and a full exponential delay)
+func (c *Cache) backoff(round int) time.Duration {
+	d := c.backoffMax
+	if round < 32 {
+		if exp := c.backoffBase << uint(round-1); exp > 0 && exp < d {
+			d = exp
+		}
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// sleep waits for a duration or until a context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+	"github.com/navibyte/quake/pkg/earthquakes/usgs/usgstest"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	c := newTestCache(WithBreaker(2, timeout),
+		WithBackoff(time.Millisecond, 10*time.Millisecond))
+	u, _ := url.Parse(fake.URL)
+	host := u.Host
+	fake.FailNext(2, 503)
+	defer fake.Reset()
+
+	// consecutive failures open the breaker
+	day := pb.Past_PAST_DAY
+	ctx := context.Background()
+	if _, err := c.getList(ctx, pb.Magnitude_MAGNITUDE_M10_PLUS, day); err == nil {
+		t.Fatal("expected an error")
+	}
+	if st := c.getBreakerStat(host); st.state != breakerOpen || st.openCount != 1 {
+		t.Fatalf("breaker should be open, got %v", st.state)
+	}
+
+	// the breaker is shared by entries of the same host
+	requests := fake.Requests("4.5_day")
+	magnitude := pb.Magnitude_MAGNITUDE_M45_PLUS
+	_, err := c.getList(ctx, magnitude, day)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	var uerr *earthquakes.UpstreamError
+	if !errors.As(err, &uerr) || uerr.Reason != earthquakes.ReasonCircuitOpen ||
+		uerr.RetryAt.Before(time.Now()) {
+		t.Error("expected an upstream error with a retry time")
+	}
+	if fake.Requests("4.5_day") != requests {
+		t.Error("should not fetch when the breaker is open")
+	}
+	if st := c.getStat(magnitude, day); st.rejectCount != 1 ||
+		st.breaker != breakerOpen {
+		t.Error("invalid reject count or breaker state on stats")
+	}
+
+	// after a timeout a probe is let through (half-open), closing the breaker
+	time.Sleep(timeout)
+	if _, err := c.getList(ctx, magnitude, day); err != nil {
+		t.Fatal(err)
+	}
+	st := c.getBreakerStat(host)
+	if st.state != breakerClosed || st.probeCount != 1 {
+		t.Errorf("breaker should be closed, got %v", st.state)
+	}
+}
+
+func TestBreakerQueryAndDetail(t *testing.T) {
+	c := newTestCache(WithBreaker(1, time.Hour))
+	fake.FailNext(1, 503)
+	defer fake.Reset()
+
+	// a failed query opens the breaker for the host
+	ctx := context.Background()
+	q := earthquakes.Query{Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS}
+	start := time.Now().Add(-time.Hour).Unix()
+	if _, err := c.fetchQuery(ctx, q, start, 0, nil, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	// then queries and detail feeds are rejected without fetching
+	requests := fake.Requests(usgstest.QueryFeed)
+	if _, err := c.fetchQuery(ctx, q, start, 0, nil, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if fake.Requests(usgstest.QueryFeed) != requests {
+		t.Error("should not query when the breaker is open")
+	}
+	detail := fake.URL + "/earthquakes/feed/v1.0/detail/us70006tf3.geojson"
+	if _, err := c.fetchDetail(ctx, detail); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	b := &breaker{threshold: 1, timeout: time.Minute}
+	now := time.Now()
+	if err := b.allow(now); err != nil {
+		t.Fatal(err)
+	}
+	b.failure(now)
+	if err := b.allow(now); err != ErrCircuitOpen {
+		t.Error("expected ErrCircuitOpen")
+	}
+
+	// only one probe at a time, and a failed probe opens again
+	later := now.Add(time.Minute)
+	if err := b.allow(later); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.allow(later); err != ErrCircuitOpen {
+		t.Error("expected ErrCircuitOpen for a second probe")
+	}
+	b.failure(later)
+	if b.state != breakerOpen || b.openCount != 2 {
+		t.Error("breaker should be open after a failed probe")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := newTestCache(WithBackoff(100*time.Millisecond, time.Second))
+	tests := []struct {
+		round    int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{100, 500 * time.Millisecond, time.Second},
+	}
+	for _, test := range tests {
+		if d := c.backoff(test.round); d < test.min || d > test.max {
+			t.Errorf("invalid backoff %v for round %d", d, test.round)
+		}
+	}
+
+	// sleeping is canceled with a context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleep(ctx, time.Hour); err != context.Canceled {
+		t.Error("expected context.Canceled")
+	}
+}
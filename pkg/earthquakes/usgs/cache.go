@@ -4,38 +4,84 @@
 package usgs
 
 import (
+	"context"
 	"errors"
+	"log"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
 )
 
 // stat contains statistics about an cache entry
 type stat struct {
-	fetchCount int
-	hitCount   int
+	fetchCount       int
+	notModifiedCount int
+	hitCount         int
+	staleCount       int
+	rejectCount      int          // refreshes rejected by an open breaker
+	breaker          breakerState // state of a breaker after the latest refresh
 }
 
 // entry for caching fetched&parsed responses
 type entry struct {
-	mu                 sync.Mutex
-	col                *pb.EarthquakeCollection
-	expires            time.Time
-	errCountSinceReset int
-	lastErrTime        time.Time
-	lastErr            error
+	mu        sync.Mutex
+	cache     *Cache
+	magnitude pb.Magnitude
+	past      pb.Past
+	col       *pb.EarthquakeCollection
+	expires   time.Time
+	ttl       time.Duration
+
+	// validators and size of the latest response (for conditional requests)
+	resource resource
+
+	// time of the latest request for the entry (to detect hot entries)
+	lastRequest time.Time
+
+	// index from ids to earthquakes for the indexed collection
+	index   map[string]*pb.Earthquake
+	indexed *pb.EarthquakeCollection
+
+	// spatial index for the spatially indexed collection (see spatial.go)
+	spatial        *spatialIndex
+	spatialIndexed *pb.EarthquakeCollection
+
+	// source collection that col is derived from (if deriving from "all")
+	source *pb.EarthquakeCollection
+
+	// refresh in flight (nil if no refresh is active)
+	refreshing *refresh
+
+	// watchers notified with events when a collection is refreshed
+	watchers map[*watcher]struct{}
 
 	stat
 }
 
+// refresh is an active refresh of an entry, done closed when finished
+type refresh struct {
+	done chan struct{}
+	col  *pb.EarthquakeCollection
+	err  error
+}
+
+// refreshTimeout is a maximum duration for a refresh (with all tries)
+const refreshTimeout = 30 * time.Second
+
 const (
-	maxTriesForRequest = 3
-	maxErrorsTotal     = 10
-	waitBeforeReset    = time.Hour
+	defaultMaxTriesForRequest = 3
+	defaultMaxStale           = 24 * time.Hour
 )
 
-var (
+// Cache caches collections fetched from the USGS feeds (for all combinations
+// of magnitude and past), synchronizing fetches so that only one fetch is
+// active for each feed. Settings are set by options on NewCache, or by
+// setters that should be called at startup before serving any requests.
+type Cache struct {
 	// cache entries identified by key generated by resolveCacheKey()
 	// (access to each entry is synchronized by a mutex for a key)
 	entries map[string]*entry
@@ -43,155 +89,524 @@ var (
 	// copies of entry stat (synchronized by one RW-mutex)
 	statMutex  sync.RWMutex
 	statCopies map[string]stat
-)
 
-// ErrCacheFailure is returned on cache failures
-var ErrCacheFailure = errors.New("failure on caching earthquake collection")
+	// policy resolving a time to live for fetched collections
+	ttl TTLPolicy
 
-// ErrNotFound is returned when identified earthquake was not found
-var ErrNotFound = errors.New("earthquake not found")
+	// tries for a request (failing hosts are throttled by breakers instead
+	// of counting errors for each entry)
+	maxTriesForRequest int
+
+	// circuit breakers by upstream hosts (synchronized by a mutex), opened
+	// after a threshold of consecutive failures for a timeout
+	breakerMutex     sync.Mutex
+	breakers         map[string]*breaker
+	breakerThreshold int
+	breakerTimeout   time.Duration
+
+	// exponential backoff between tries for a request
+	backoffBase time.Duration
+	backoffMax  time.Duration
+
+	// maximum duration after expiry to serve stale data (0 = no limit)
+	maxStale time.Duration
+
+	// whether feeds by magnitude are derived from the "all" feed (see derive.go)
+	deriveFromAll bool
+
+	// directory for the disk store, or empty if disabled (see store.go)
+	storeDir string
+
+	// base URL of the USGS web services and a client to fetch data with
+	baseURL    string
+	httpClient *http.Client
+
+	// products cached for earthquakes (see products.go)
+	products *productCache
+}
+
+// CacheOption sets an option on a cache created by NewCache.
+type CacheOption func(*Cache)
+
+// WithTTLPolicy sets a policy resolving a time to live for fetched
+// collections (DefaultTTL by default).
+func WithTTLPolicy(policy TTLPolicy) CacheOption {
+	return func(c *Cache) {
+		c.ttl = policy
+	}
+}
+
+// WithMaxAge sets a policy resolving a time to live for fetched collections by
+// a magnitude and a past of a feed (adapted as a TTLPolicy, see WithTTLPolicy).
+func WithMaxAge(
+	policy func(magnitude pb.Magnitude, past pb.Past) time.Duration) CacheOption {
+
+	return WithTTLPolicy(func(info RefreshInfo) time.Duration {
+		return policy(info.Magnitude, info.Past)
+	})
+}
+
+// WithMaxTries sets a number of tries (with backoff) for a request.
+func WithMaxTries(maxTriesForRequest int) CacheOption {
+	return func(c *Cache) {
+		c.maxTriesForRequest = maxTriesForRequest
+	}
+}
+
+// WithErrorBudget sets a number of tries for a request, and a number of
+// errors before giving up fetching and a time to wait before trying again
+// as a threshold and a timeout of breakers for upstream hosts (errors are
+// counted as consecutive failures for a host, not for a feed).
+//
+// Deprecated: use WithMaxTries and WithBreaker instead.
+func WithErrorBudget(maxTriesForRequest, maxErrorsTotal int,
+	waitBeforeReset time.Duration) CacheOption {
 
-// init cache entries for all key combinations
-func init() {
-	// init entries
-	entries = make(map[string]*entry)
+	return func(c *Cache) {
+		WithMaxTries(maxTriesForRequest)(c)
+		WithBreaker(maxErrorsTotal, waitBeforeReset)(c)
+	}
+}
+
+// WithBreaker sets a number of consecutive failures opening a circuit breaker
+// for an upstream host, and a timeout after that the breaker lets a probe
+// through (half-open) to test whether the host has recovered.
+func WithBreaker(threshold int, timeout time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.breakerThreshold = threshold
+		c.breakerTimeout = timeout
+	}
+}
+
+// WithBackoff sets a base and a maximum for exponential backoff (with jitter)
+// between tries for a request.
+func WithBackoff(base, max time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.backoffBase = base
+		c.backoffMax = max
+	}
+}
+
+// WithMaxStale sets a maximum duration after expiry that stale data is served
+// (see SetMaxStale).
+func WithMaxStale(d time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.maxStale = d
+	}
+}
+
+// WithDeriveFromAll enables (or disables) deriving feeds by magnitude from the
+// "all" feed (see SetDeriveFromAll).
+func WithDeriveFromAll(enabled bool) CacheOption {
+	return func(c *Cache) {
+		c.deriveFromAll = enabled
+	}
+}
+
+// WithBaseURL sets a base URL (like "https://earthquake.usgs.gov") of the USGS
+// web services that summary feeds and queries are fetched from (see
+// SetBaseURL).
+func WithBaseURL(url string) CacheOption {
+	return func(c *Cache) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHTTPClient sets a HTTP client used to fetch data from the USGS web
+// services (see SetHTTPClient).
+func WithHTTPClient(client *http.Client) CacheOption {
+	return func(c *Cache) {
+		c.httpClient = client
+	}
+}
+
+// NewCache creates a new cache with entries for all combinations of magnitude
+// and past.
+func NewCache(opts ...CacheOption) *Cache {
+	c := &Cache{
+		entries:            make(map[string]*entry),
+		statCopies:         make(map[string]stat),
+		ttl:                DefaultTTL,
+		maxTriesForRequest: defaultMaxTriesForRequest,
+		breakers:           make(map[string]*breaker),
+		breakerThreshold:   defaultBreakerThreshold,
+		breakerTimeout:     defaultBreakerTimeout,
+		backoffBase:        defaultBackoffBase,
+		backoffMax:         defaultBackoffMax,
+		maxStale:           defaultMaxStale,
+		baseURL:            defaultBaseURL,
+		httpClient:         &http.Client{Timeout: defaultHTTPTimeout},
+		products:           newProductCache(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
 	for _, magn := range pb.Magnitude_value {
 		for _, past := range pb.Past_value {
-			key := resolveCacheKey(pb.Magnitude(magn), pb.Past(past))
-			entries[key] = &entry{}
+			magnitude, past := pb.Magnitude(magn), pb.Past(past)
+			key := resolveCacheKey(magnitude, past)
+			c.entries[key] = &entry{cache: c, magnitude: magnitude, past: past}
 		}
 	}
-	// init stat
-	statCopies = make(map[string]stat)
+	return c
 }
 
-// cacheGetById returns a single earthquake (cached or fetched if no cache hit)
-func cacheGetById(id string) (*pb.Earthquake, error) {
+// defaultCache is a cache used by package functions
+var defaultCache = NewCache()
 
-	// loop "hour", "day", "7days" and "30days" cached lists to find identified one
-	var lastErr error
-	for _, past := range pb.Past_value {
-		if pb.Past(past) != pb.Past_PAST_UNSPECIFIED {
-			// get full collection for given "past" value
-			col, err := cacheGetList(
-				pb.Magnitude_MAGNITUDE_ALL, pb.Past(past))
-			if err != nil {
-				lastErr = err
-			} else {
-				// got collection, loop to find matching with id
-				for _, eq := range col.Features {
-					if eq.Id == id {
-						return eq, nil
-					}
-				}
-			}
+// ErrCacheFailure is returned on cache failures
+var ErrCacheFailure = errors.New("failure on caching earthquake collection")
+
+// ErrNotFound is returned when identified earthquake was not found
+var ErrNotFound = earthquakes.ErrNotFound
+
+// SetMaxStale sets a maximum duration after expiry that stale data is served
+// (while refreshing it or if refreshing fails). After that requests fail
+// until data is refreshed successfully. If 0 there is no limit. This should
+// be called at startup before serving any requests.
+func SetMaxStale(d time.Duration) {
+	defaultCache.maxStale = d
+}
+
+// SetTTLPolicy sets a policy resolving a time to live for fetched collections
+// (DefaultTTL by default). This should be called at startup before serving
+// any requests.
+func SetTTLPolicy(policy TTLPolicy) {
+	defaultCache.ttl = policy
+}
+
+// getById returns a single earthquake (cached or fetched if no cache hit)
+// found by its preferred id or any other id associated to it
+func (c *Cache) getById(ctx context.Context, id string) (*pb.Earthquake, error) {
+
+	// the "30days" list contains all earthquakes on shorter lists too, so it
+	// is fetched if needed, however "hour", "day" and "7days" lists already
+	// cached (if any) are searched first as they are refreshed more often
+	// (and may have earthquakes not yet on a "30days" list served stale)
+	_, err := c.getList(ctx, pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_30DAYS)
+	for _, past := range feedPasts {
+		entry := c.entries[resolveCacheKey(pb.Magnitude_MAGNITUDE_ALL, past)]
+		if eq := entry.lookup(id); eq != nil {
+			return eq, nil
 		}
 	}
-
-	if lastErr != nil {
-		return nil, lastErr
+	if err != nil {
+		return nil, err
 	}
 	return nil, ErrNotFound
 }
 
-// cacheGetList returns cached data from entry (or fetched data if no cache hit)
-func cacheGetList(magnitude pb.Magnitude, past pb.Past) (
-	*pb.EarthquakeCollection, error) {
+// getList returns cached data from entry (or fetched data if no cache hit),
+// or an error of the context if it's done before fetched data is available
+// (a fetch shared by other callers is not canceled)
+func (c *Cache) getList(ctx context.Context, magnitude pb.Magnitude,
+	past pb.Past) (*pb.EarthquakeCollection, error) {
+
+	// feeds by magnitude derived from "all" feeds if enabled
+	if c.deriveFromAll && magnitude != pb.Magnitude_MAGNITUDE_ALL {
+		return c.getDerived(ctx, magnitude, past)
+	}
 
 	// resolve cache key and entry
 	key := resolveCacheKey(magnitude, past)
-	entry := entries[key]
+	entry := c.entries[key]
 	if entry == nil {
 		return nil, ErrCacheFailure
 	}
 
-	// synchronize access to an entry identified by the key
-	// (note that it's on purpose to acquire lock for all the time
-	// needed to access cache entry and to fecth/parse data if needed)
+	// synchronize access to an entry identified by the key (however fetching
+	// and parsing data is done on a refresh without holding the lock)
 	entry.mu.Lock()
-	defer entry.mu.Unlock()
+	now := time.Now()
+	entry.lastRequest = now
 
-	// return cached data if available and not yet expired
 	if entry.col != nil {
-		if time.Now().After(entry.expires) {
-			entry.col = nil
-		} else {
+		// return cached data if available and not yet expired
+		if now.Before(entry.expires) {
 			// cache hit
 			entry.hitCount++
-			cacheSetStat(magnitude, past, entry.stat)
-			return entry.col, nil
+			c.setStat(magnitude, past, entry.stat)
+			col := entry.col
+			entry.mu.Unlock()
+			return col, nil
+		}
+
+		// return stale data (not too stale) and refresh it on background
+		if entry.isServable(now) {
+			entry.startRefresh()
+			entry.staleCount++
+			c.setStat(magnitude, past, entry.stat)
+			col := entry.col
+			entry.mu.Unlock()
+			return col, nil
 		}
 	}
 
-	// if maximum number of errors occurred some time ago, reset error counters
-	if entry.errCountSinceReset >= maxErrorsTotal &&
-		time.Now().After(entry.lastErrTime.Add(waitBeforeReset)) {
+	// could not get valid cache entry, so need to wait for a refresh (unless
+	// the caller gives up, however the refresh continues for other callers)
+	r := entry.startRefresh()
+	entry.mu.Unlock()
+	select {
+	case <-r.done:
+		return r.col, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// isServable returns true if a collection of the entry is available and not
+// too stale to be served (must be called when holding a lock)
+func (e *entry) isServable(now time.Time) bool {
+	maxStale := e.cache.maxStale
+	return e.col != nil &&
+		(maxStale <= 0 || now.Before(e.expires.Add(maxStale)))
+}
 
-		entry.errCountSinceReset = 0
-		entry.lastErr = nil
+// startRefresh starts a refresh for an entry unless already refreshing and
+// returns the active refresh (must be called when holding a lock)
+func (e *entry) startRefresh() *refresh {
+	if e.refreshing != nil {
+		return e.refreshing
 	}
 
-	// could not get valid cache entry, so need to fetch data
-	// (trying to fetch&parse for few times before giving up)
-	round := 0
-	for round < maxTriesForRequest && entry.errCountSinceReset < maxErrorsTotal {
-		data, err := fetch(magnitude, past)
-		if err != nil { // fetch error
-			entry.errCountSinceReset++
-			entry.lastErr = err
-			entry.lastErrTime = time.Now()
-		} else {
+	// conditional requests only if having data to be validated
+	var prev resource
+	if e.col != nil {
+		prev = e.resource
+	}
+
+	// a refresh is shared by callers, so it's not canceled by any of them,
+	// but tries (with backoff) are limited by a timeout
+	r := &refresh{done: make(chan struct{})}
+	e.refreshing = r
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		e.refresh(ctx, r, prev)
+	}()
+	return r
+}
+
+// refresh fetches and parses data for an entry and finishes a refresh r
+// (using a conditional request if validators on prev are set)
+func (e *entry) refresh(ctx context.Context, r *refresh, prev resource) {
+
+	// a breaker shared by all fetches from the same upstream host (tries are
+	// rejected by c.fetch when it is open, see fetchThroughBreaker)
+	c := e.cache
+	url, err := c.resolveURL(e.magnitude, e.past)
+	if err != nil {
+		// no feed for a magnitude and a past, so nothing to fetch or retry
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		defer close(r.done)
+		e.refreshing = nil
+		r.err = err
+		return
+	}
+	b := c.breaker(url)
+
+	// fetch&parse without holding a lock (trying upstream errors for few times
+	// with backoff before giving up), so that stale data can be served while
+	// refreshing
+	var resp *response
+	var col *pb.EarthquakeCollection
+	var lastErr error
+	var stopped error // an open breaker or a context error stopping tries
+	for round := 0; round < c.maxTriesForRequest; round++ {
+		if round > 0 {
+			if err := sleep(ctx, c.backoff(round)); err != nil {
+				stopped = err
+				break
+			}
+		}
+		var err error
+		resp, err = c.fetch(ctx, e.magnitude, e.past, prev)
+		switch {
+		case errors.Is(err, ErrCircuitOpen):
+			stopped = err
+		case err != nil && ctx.Err() != nil:
+			stopped = ctx.Err()
+		}
+		if stopped != nil {
+			resp = nil
+			break
+		}
+		if err == nil && !resp.notModified {
 			// fetched data successfully, now trying to parse it
-			col, err := ToEarthquakeCollection(data, true)
-			if err != nil { // parse error
-				entry.errCountSinceReset++
-				entry.lastErr = err
-				entry.lastErrTime = time.Now()
-			} else {
-				// got valid response, store to the cache entry and return it
-				entry.col = col
-				entry.fetchCount++
-				cacheSetStat(magnitude, past, entry.stat)
-				entry.expires = time.Now().Add(resolveMaxAge(magnitude, past))
-				entry.errCountSinceReset = 0
-				entry.lastErr = nil
-				return col, nil
+			col, err = ToEarthquakeCollection(resp.data, true)
+			if err != nil {
+				err = invalidData(url, err)
 			}
 		}
-		round++
+		if err == nil {
+			break
+		}
+		resp = nil
+		lastErr = err
+		var uerr *earthquakes.UpstreamError
+		if !errors.As(err, &uerr) {
+			// not an upstream error (like a request not valid), not retried
+			break
+		}
 	}
 
-	// did not succeed on getting valid response, return last error
-	if entry.lastErr == nil {
-		return nil, ErrCacheFailure
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer close(r.done)
+	e.refreshing = nil
+
+	key := resolveCacheKey(e.magnitude, e.past)
+	if errors.Is(stopped, ErrCircuitOpen) {
+		e.rejectCount++
+	}
+	e.stat.breaker = b.stat().state
+	c.setStat(e.magnitude, e.past, e.stat)
+	if resp != nil && resp.notModified && e.col != nil {
+		// data not modified, so just extend expiry of data cached
+		e.notModifiedCount++
+		c.setStat(e.magnitude, e.past, e.stat)
+		e.ttl = c.ttl(e.resolveRefreshInfo(nil, resp.maxAge))
+		e.expires = time.Now().Add(e.ttl)
+		e.saveToStore(key)
+		r.col = e.col
+		return
+	}
+	if col != nil {
+		// got valid response, notify watchers about changes and resolve a
+		// time to live (that may depend on changes)
+		var events []*pb.EarthquakeEvent
+		if e.col != nil || len(e.watchers) > 0 {
+			events = diffCollections(e.col, col)
+		}
+		if len(e.watchers) > 0 {
+			e.notify(events)
+		}
+		e.ttl = c.ttl(e.resolveRefreshInfo(events, resp.maxAge))
+
+		// store to the cache entry and set it as a result of the refresh
+		e.col = col
+		e.resource = resp.resource
+		e.fetchCount++
+		c.setStat(e.magnitude, e.past, e.stat)
+		e.expires = time.Now().Add(e.ttl)
+		e.saveToStore(key)
+		r.col = col
+		return
+	}
+
+	// did not succeed on getting valid response, so fall back to a stale
+	// collection fetched earlier (or loaded from the store) if not too stale
+	if e.isServable(time.Now()) {
+		if lastErr == nil {
+			lastErr = stopped
+		}
+		log.Printf("serving stale %s after error %v", key, lastErr)
+		r.col = e.col
+		return
+	}
+
+	// no fallback either, return a reason for stopping or last error
+	if stopped != nil {
+		if !errors.Is(stopped, ErrCircuitOpen) {
+			// a refresh timed out (that is not a timeout of a caller)
+			stopped = unavailable(url, stopped)
+		}
+		r.err = stopped
+	} else if lastErr == nil {
+		r.err = ErrCacheFailure
+	} else {
+		r.err = lastErr
+	}
+}
+
+// saveToStore saves an entry to the disk store if enabled (must be called
+// when holding a lock)
+func (e *entry) saveToStore(key string) {
+	if dir := e.cache.storeDir; dir != "" {
+		if err := storeSave(dir, key, e); err != nil {
+			log.Printf("error %v saving %s to the store", err, key)
+		}
+	}
+}
+
+// watch registers a new watcher for an entry and returns it with the latest
+// collection cached (must be called after a successful getList)
+func (c *Cache) watch(magnitude pb.Magnitude, past pb.Past) (
+	*watcher, *pb.EarthquakeCollection, error) {
+
+	entry := c.entries[resolveCacheKey(magnitude, past)]
+	if entry == nil {
+		return nil, nil, ErrCacheFailure
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.col == nil {
+		return nil, nil, ErrCacheFailure
+	}
+	w := newWatcher()
+	if entry.watchers == nil {
+		entry.watchers = make(map[*watcher]struct{})
+	}
+	entry.watchers[w] = struct{}{}
+	return w, entry.col, nil
+}
+
+// unwatch unregisters a watcher from an entry
+func (c *Cache) unwatch(magnitude pb.Magnitude, past pb.Past, w *watcher) {
+	entry := c.entries[resolveCacheKey(magnitude, past)]
+	if entry == nil {
+		return
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if _, ok := entry.watchers[w]; ok {
+		delete(entry.watchers, w)
+		w.close()
+	}
+}
+
+// notify sends events to watchers (must be called when holding a lock)
+func (e *entry) notify(events []*pb.EarthquakeEvent) {
+	if len(events) == 0 {
+		return
+	}
+	for w := range e.watchers {
+		select {
+		case w.events <- events:
+		default:
+			// watcher is lagging behind, so drop it (closing the channel)
+			delete(e.watchers, w)
+			w.close()
+		}
 	}
-	return nil, entry.lastErr
 }
 
-// cacheGetStat returns latest statistics about an entry
-func cacheGetStat(magnitude pb.Magnitude, past pb.Past) stat {
+// getStat returns latest statistics about an entry
+func (c *Cache) getStat(magnitude pb.Magnitude, past pb.Past) stat {
 	// when reading acquire a read lock for statistics
-	statMutex.RLock()
-	defer statMutex.RUnlock()
-	st, ok := statCopies[resolveCacheKey(magnitude, past)]
+	c.statMutex.RLock()
+	defer c.statMutex.RUnlock()
+	st, ok := c.statCopies[resolveCacheKey(magnitude, past)]
 	if !ok {
 		return stat{}
 	}
 	return st
 }
 
-// cacheSetStat sets latest statistics fon an entry
-func cacheSetStat(magnitude pb.Magnitude, past pb.Past, st stat) {
+// setStat sets latest statistics fon an entry
+func (c *Cache) setStat(magnitude pb.Magnitude, past pb.Past, st stat) {
 	// when writing acquire a regular lock for statistics
-	statMutex.Lock()
-	defer statMutex.Unlock()
-	statCopies[resolveCacheKey(magnitude, past)] = st
+	c.statMutex.Lock()
+	defer c.statMutex.Unlock()
+	c.statCopies[resolveCacheKey(magnitude, past)] = st
 }
 
 func resolveCacheKey(magnitude pb.Magnitude, past pb.Past) string {
 	return magnitude.String() + "@" + past.String()
 }
 
+// resolveMaxAge is the default time to live policy (by past of a feed)
 func resolveMaxAge(magnitude pb.Magnitude, past pb.Past) time.Duration {
 	switch past {
 	case pb.Past_PAST_HOUR:
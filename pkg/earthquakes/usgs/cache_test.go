@@ -4,9 +4,13 @@
 package usgs
 
 import (
+	"context"
+	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
 
 	pb "github.com/navibyte/quake/api/v1"
 )
@@ -18,11 +22,12 @@ func TestCacheGet(t *testing.T) {
 
 func testCacheGet(t *testing.T, magnitude pb.Magnitude, past pb.Past) {
 	// get data from a cache (that fetches data from USGS web service if needed)
-	col1, err := cacheGetList(magnitude, past)
+	c := newTestCache()
+	col1, err := c.getList(context.Background(), magnitude, past)
 	if err != nil {
 		t.Fatal(err)
 	}
-	stat1 := cacheGetStat(magnitude, past)
+	stat1 := c.getStat(magnitude, past)
 	if stat1.fetchCount != 1 || stat1.hitCount != 0 {
 		t.Error("invalid cache fetch or hit count")
 	}
@@ -40,11 +45,11 @@ func testCacheGet(t *testing.T, magnitude pb.Magnitude, past pb.Past) {
 	}
 
 	// get data again, this should come from a cache
-	col2, err := cacheGetList(magnitude, past)
+	col2, err := c.getList(context.Background(), magnitude, past)
 	if err != nil {
 		t.Fatal(err)
 	}
-	stat2 := cacheGetStat(magnitude, past)
+	stat2 := c.getStat(magnitude, past)
 	if stat2.fetchCount != 1 || stat2.hitCount != 1 {
 		t.Error("invalid cache fetch or hit count")
 	}
@@ -53,3 +58,222 @@ func testCacheGet(t *testing.T, magnitude pb.Magnitude, past pb.Past) {
 	}
 
 }
+
+func TestCacheServeStale(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// set an expired (but not too stale) collection on an entry
+	c := newTestCache()
+	magnitude, past := pb.Magnitude_MAGNITUDE_SIGNIFICANT, pb.Past_PAST_30DAYS
+	entry := c.entries[resolveCacheKey(magnitude, past)]
+	entry.mu.Lock()
+	entry.col = col
+	entry.expires = time.Now().Add(-time.Minute)
+	entry.mu.Unlock()
+
+	// stale data should be returned without waiting for a refresh
+	stale, err := c.getList(context.Background(), magnitude, past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale != col {
+		t.Error("stale collection not returned")
+	}
+	if c.getStat(magnitude, past).staleCount != 1 {
+		t.Error("invalid cache stale count")
+	}
+
+	// wait for a refresh started on background
+	entry.mu.Lock()
+	r := entry.refreshing
+	entry.mu.Unlock()
+	if r == nil {
+		t.Fatal("no refresh started")
+	}
+	<-r.done
+}
+
+func TestNeedsRefreshAhead(t *testing.T) {
+	now := time.Now()
+	e := &entry{
+		cache:       newTestCache(),
+		magnitude:   pb.Magnitude_MAGNITUDE_ALL,
+		past:        pb.Past_PAST_HOUR,
+		col:         &pb.EarthquakeCollection{},
+		lastRequest: now.Add(-time.Minute),
+	}
+
+	// max age for the past hour is 3 minutes, so refreshed 36 s before expiry
+	e.expires = now.Add(30 * time.Second)
+	if !e.needsRefreshAhead(now) {
+		t.Error("hot entry expiring soon should be refreshed")
+	}
+	e.expires = now.Add(2 * time.Minute)
+	if e.needsRefreshAhead(now) {
+		t.Error("hot entry not expiring soon should not be refreshed")
+	}
+	e.expires = now.Add(30 * time.Second)
+	e.lastRequest = now.Add(-time.Hour)
+	if e.needsRefreshAhead(now) {
+		t.Error("entry not hot should not be refreshed")
+	}
+}
+
+func TestCacheRetries(t *testing.T) {
+	c := newTestCache(WithBackoff(time.Millisecond, 10*time.Millisecond))
+	magnitude, past := pb.Magnitude_MAGNITUDE_M10_PLUS, pb.Past_PAST_DAY
+	requests := fake.Requests("1.0_day")
+
+	// a dropped connection and malformed JSON are retried on a same request
+	fake.DropNext(1)
+	fake.MalformNext(1)
+	col, err := c.getList(context.Background(), magnitude, past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 21 {
+		t.Error("invalid feature count")
+	}
+	if fake.Requests("1.0_day")-requests != c.maxTriesForRequest {
+		t.Error("invalid request count")
+	}
+}
+
+func TestCacheInvalidRequest(t *testing.T) {
+	// a request not valid fails without retries (and backoff between them)
+	c := newTestCache(WithBackoff(time.Second, 10*time.Second))
+	started := time.Now()
+	_, err := c.getList(context.Background(), pb.Magnitude_MAGNITUDE_UNSPECIFIED,
+		pb.Past_PAST_DAY)
+	if err != ErrUnknownDataRequest {
+		t.Errorf("expected ErrUnknownDataRequest, got %v", err)
+	}
+	if time.Since(started) > time.Second/2 {
+		t.Error("a request not valid should not be retried")
+	}
+}
+
+func TestCacheFailures(t *testing.T) {
+	// failures are not counted for an entry, only a breaker (with a default
+	// threshold not reached on this test) throttles fetches from a host
+	const maxTries = 2
+	c := newTestCache(WithMaxTries(maxTries),
+		WithBackoff(time.Millisecond, 10*time.Millisecond))
+	magnitude, past := pb.Magnitude_MAGNITUDE_M10_PLUS, pb.Past_PAST_7DAYS
+	const feed = "1.0_week"
+	fake.FailNext(2*maxTries, 503)
+	defer fake.Reset()
+
+	// requests fail (after retries), but each of them is tried again
+	ctx := context.Background()
+	requests := fake.Requests(feed)
+	for i := 1; i <= 2; i++ {
+		if _, err := c.getList(ctx, magnitude, past); err == nil {
+			t.Fatal("expected an error")
+		}
+		if fake.Requests(feed)-requests != i*maxTries {
+			t.Fatalf("invalid request count %d", fake.Requests(feed))
+		}
+	}
+
+	// fetching succeeds as soon as the host recovers
+	col, err := c.getList(ctx, magnitude, past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col == nil || fake.Requests(feed)-requests != 2*maxTries+1 {
+		t.Error("should fetch after failures")
+	}
+}
+
+func TestCacheOptions(t *testing.T) {
+	// caches do not share entries, and time to live is set by a policy
+	ttl := func(info RefreshInfo) time.Duration {
+		return time.Hour
+	}
+	c1 := newTestCache(WithTTLPolicy(ttl))
+	c2 := newTestCache()
+	magnitude, past := pb.Magnitude_MAGNITUDE_M45_PLUS, pb.Past_PAST_DAY
+	requests := fake.Requests("4.5_day")
+	r := NewRepositoryWithCache(c1)
+	q := earthquakes.Query{Magnitude: magnitude, Past: past, Details: true}
+	for i := 0; i < 2; i++ {
+		if _, _, err := r.ListEarthquakes(context.Background(), q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c2.getList(context.Background(), magnitude, past); err != nil {
+		t.Fatal(err)
+	}
+	if fake.Requests("4.5_day")-requests != 2 {
+		t.Error("invalid request count")
+	}
+	if st := c1.getStat(magnitude, past); st.fetchCount != 1 || st.hitCount != 1 {
+		t.Error("invalid cache fetch or hit count")
+	}
+	if st := c2.getStat(magnitude, past); st.fetchCount != 1 || st.hitCount != 0 {
+		t.Error("invalid cache fetch or hit count")
+	}
+	entry := c1.entries[resolveCacheKey(magnitude, past)]
+	entry.mu.Lock()
+	expires := entry.expires
+	entry.mu.Unlock()
+	if expires.Before(time.Now().Add(50 * time.Minute)) {
+		t.Error("time to live policy not applied")
+	}
+
+	// an error budget is mapped on tries and breakers
+	c4 := newTestCache(WithErrorBudget(2, 5, time.Minute))
+	if c4.maxTriesForRequest != 2 || c4.breakerThreshold != 5 ||
+		c4.breakerTimeout != time.Minute {
+		t.Error("error budget not applied")
+	}
+
+	// a policy by a magnitude and a past is adapted as a time to live policy
+	maxAge := func(magnitude pb.Magnitude, past pb.Past) time.Duration {
+		return 2 * time.Hour
+	}
+	c3 := newTestCache(WithMaxAge(maxAge))
+	if c3.ttl(RefreshInfo{Magnitude: magnitude, Past: past}) != 2*time.Hour {
+		t.Error("max age policy not applied")
+	}
+}
+
+func TestCacheCanceled(t *testing.T) {
+	c := newTestCache()
+	magnitude, past := pb.Magnitude_MAGNITUDE_M25_PLUS, pb.Past_PAST_DAY
+	requests := fake.Requests("2.5_day")
+	fake.SetLatency(200 * time.Millisecond)
+	defer fake.Reset()
+
+	// a caller waiting for a shared fetch gives up on its deadline
+	result := make(chan error, 1)
+	go func() {
+		_, err := c.getList(context.Background(), magnitude, past)
+		result <- err
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.getList(ctx, magnitude, past); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 150*time.Millisecond {
+		t.Error("should not wait for the fetch after the deadline")
+	}
+
+	// however the fetch continues for another caller
+	if err := <-result; err != nil {
+		t.Fatal(err)
+	}
+	if fake.Requests("2.5_day")-requests != 1 {
+		t.Error("invalid request count")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// SetDeriveFromAll enables (or disables) a mode where only the "all" feed is
+// fetched for each past period, and feeds by magnitude are derived from it.
+// This should be called at startup before serving any requests.
+func SetDeriveFromAll(enabled bool) {
+	defaultCache.deriveFromAll = enabled
+}
+
+// getDerived returns a collection derived from the "all" collection for the
+// same past (cached until the "all" collection is refreshed)
+func (c *Cache) getDerived(ctx context.Context, magnitude pb.Magnitude,
+	past pb.Past) (*pb.EarthquakeCollection, error) {
+
+	// resolve cache entry for a feed to be derived
+	entry := c.entries[resolveCacheKey(magnitude, past)]
+	if entry == nil {
+		return nil, ErrCacheFailure
+	}
+	if _, ok := resolveDeriveFilter(magnitude); !ok {
+		return nil, ErrUnknownDataRequest
+	}
+
+	// get the "all" collection as a source (that is cached or fetched)
+	all, err := c.getList(ctx, pb.Magnitude_MAGNITUDE_ALL, past)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.col != nil && entry.source == all {
+		// cache hit (derived from the current source)
+		entry.hitCount++
+		c.setStat(magnitude, past, entry.stat)
+		return entry.col, nil
+	}
+
+	// source changed, so derive again and notify watchers about changes
+	col := c.deriveCollection(all, magnitude, past)
+	if len(entry.watchers) > 0 {
+		entry.notify(diffCollections(entry.col, col))
+	}
+	entry.col = col
+	entry.source = all
+	entry.fetchCount++
+	c.setStat(magnitude, past, entry.stat)
+	return col, nil
+}
+
+// resolveDeriveFilter returns a filter for earthquakes on a feed by magnitude
+func resolveDeriveFilter(magnitude pb.Magnitude) (func(*pb.Earthquake) bool, bool) {
+	if magnitude == pb.Magnitude_MAGNITUDE_SIGNIFICANT {
+		return func(eq *pb.Earthquake) bool {
+			return eq.Significance >= significantMin
+		}, true
+	}
+	for _, th := range feedThresholds {
+		if th.magnitude == magnitude {
+			min := th.min
+			return func(eq *pb.Earthquake) bool {
+				return eq.Magnitude >= min
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// deriveCollection derives a collection for a feed by magnitude from the "all"
+// collection for the same past
+func (c *Cache) deriveCollection(all *pb.EarthquakeCollection,
+	magnitude pb.Magnitude, past pb.Past) *pb.EarthquakeCollection {
+
+	match, _ := resolveDeriveFilter(magnitude)
+	col := &pb.EarthquakeCollection{}
+	for _, eq := range all.Features {
+		if match(eq) {
+			col.Features = append(col.Features, eq)
+		}
+	}
+	col.Bounds = earthquakes.BoundsOf(col.Features)
+	if m := all.Metadata; m != nil {
+		url, _ := c.resolveURL(magnitude, past)
+		col.Metadata = &pb.EarthquakeMetadata{
+			GeneratedTime: m.GeneratedTime,
+			Url:           url,
+			Title:         deriveTitle(m.Title, magnitude),
+			Api:           m.Api,
+			Count:         int32(len(col.Features)),
+			HttpStatus:    m.HttpStatus,
+		}
+	}
+	return col
+}
+
+// deriveTitle derives a title like "USGS Magnitude 4.5+ Earthquakes, Past Day"
+// from a title like "USGS All Earthquakes, Past Day"
+func deriveTitle(allTitle string, magnitude pb.Magnitude) string {
+	var title string
+	switch magnitude {
+	case pb.Magnitude_MAGNITUDE_SIGNIFICANT:
+		title = "USGS Significant Earthquakes"
+	case pb.Magnitude_MAGNITUDE_M45_PLUS:
+		title = "USGS Magnitude 4.5+ Earthquakes"
+	case pb.Magnitude_MAGNITUDE_M25_PLUS:
+		title = "USGS Magnitude 2.5+ Earthquakes"
+	case pb.Magnitude_MAGNITUDE_M10_PLUS:
+		title = "USGS Magnitude 1.0+ Earthquakes"
+	}
+	if i := strings.Index(allTitle, ", "); i >= 0 {
+		title += allTitle[i:]
+	}
+	return title
+}
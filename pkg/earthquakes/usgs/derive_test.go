@@ -0,0 +1,92 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestDeriveCollection(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// all earthquakes on test data are M4.5+
+	c := newTestCache()
+	col := c.deriveCollection(all, pb.Magnitude_MAGNITUDE_M45_PLUS, pb.Past_PAST_DAY)
+	if len(col.Features) != len(all.Features) {
+		t.Error("invalid feature count")
+	}
+	if col.Metadata.Title != "USGS Magnitude 4.5+ Earthquakes, Past Day" {
+		t.Errorf("invalid title: %s", col.Metadata.Title)
+	}
+	if col.Metadata.Url != fake.URL+feedPath+"4.5_day"+feedPostfix {
+		t.Errorf("invalid url: %s", col.Metadata.Url)
+	}
+
+	// only some of them are significant
+	col = c.deriveCollection(all, pb.Magnitude_MAGNITUDE_SIGNIFICANT, pb.Past_PAST_DAY)
+	for _, eq := range col.Features {
+		if eq.Significance < significantMin {
+			t.Error("earthquake not significant")
+		}
+	}
+	if int(col.Metadata.Count) != len(col.Features) {
+		t.Error("invalid count")
+	}
+}
+
+func TestCacheGetDerived(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// set a valid "all" collection on the cache and enable deriving
+	c := newTestCache(WithDeriveFromAll(true))
+	past := pb.Past_PAST_HOUR
+	source := c.entries[resolveCacheKey(pb.Magnitude_MAGNITUDE_ALL, past)]
+	source.mu.Lock()
+	source.col = all
+	source.expires = time.Now().Add(time.Minute)
+	source.mu.Unlock()
+
+	// first time derived, then a cache hit
+	magnitude := pb.Magnitude_MAGNITUDE_M25_PLUS
+	col1, err := c.getList(context.Background(), magnitude, past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col2, err := c.getList(context.Background(), magnitude, past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col1 != col2 || len(col1.Features) != len(all.Features) {
+		t.Error("invalid derived collection")
+	}
+	st := c.getStat(magnitude, past)
+	if st.fetchCount != 1 || st.hitCount != 1 {
+		t.Error("invalid cache fetch or hit count")
+	}
+
+	// unknown magnitude cannot be derived
+	if _, err := c.getList(context.Background(), pb.Magnitude_MAGNITUDE_UNSPECIFIED,
+		past); err != ErrUnknownDataRequest {
+		t.Error("expected ErrUnknownDataRequest")
+	}
+}
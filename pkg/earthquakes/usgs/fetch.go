@@ -4,101 +4,372 @@
 package usgs
 
 import (
-	"errors"
+	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
 )
 
 const (
-	apiBaseURL        = "https://earthquake.usgs.gov/earthquakes/feed/v1.0/summary/"
-	apiBaseURLPostfix = ".geojson"
+	defaultBaseURL = "https://earthquake.usgs.gov"
+	feedPath       = "/earthquakes/feed/v1.0/summary/"
+	feedPostfix    = ".geojson"
+	queryPath      = "/fdsnws/event/1/query"
 )
 
-var (
-	httpClient = &http.Client{
-		Timeout: time.Second * 10,
-	}
-)
+// significantMin is a minimum significance for significant earthquakes
+// (as used by the "significant" feeds)
+const significantMin = 600
+
+// defaultHTTPTimeout is a timeout for a client used to fetch data by default
+const defaultHTTPTimeout = 10 * time.Second
 
 // ErrUnknownDataRequest is returned by a parser when could not formulate a request
-var ErrUnknownDataRequest = errors.New("unknown earthquake data request")
+var ErrUnknownDataRequest error = &earthquakes.ValidationError{
+	Message: "unknown earthquake data request",
+}
+
+// SetBaseURL sets a base URL (like "https://earthquake.usgs.gov") of the USGS
+// web services that summary feeds and queries are fetched from by the default
+// cache (see WithBaseURL). This should be called at startup before serving
+// any requests.
+func SetBaseURL(url string) {
+	defaultCache.baseURL = strings.TrimSuffix(url, "/")
+}
+
+// SetHTTPClient sets a HTTP client used to fetch data from the USGS web
+// services by the default cache (see WithHTTPClient). This should be called
+// at startup before serving any requests.
+func SetHTTPClient(client *http.Client) {
+	defaultCache.httpClient = client
+}
+
+// resource contains validators and a size of a resource fetched earlier
+// (used to make conditional requests)
+type resource struct {
+	etag         string
+	lastModified string
+	size         int // bytes transferred (compressed if so)
+}
+
+// response for a fetch, data is nil if not modified or no content
+type response struct {
+	data        []byte
+	notModified bool
+	maxAge      time.Duration // max-age of Cache-Control (0 if not present)
+	resource
+}
 
-func fetch(magnitude pb.Magnitude, past pb.Past) ([]byte, error) {
-	url, err := resolveURL(magnitude, past)
+// fetch fetches a feed for magnitude and past, with a conditional request if
+// validators for a resource fetched earlier (prev) are available
+func (c *Cache) fetch(ctx context.Context, magnitude pb.Magnitude,
+	past pb.Past, prev resource) (*response, error) {
+
+	url, err := c.resolveURL(magnitude, past)
 	if err != nil {
 		return nil, err
 	}
 	start := time.Now()
-	data, err := fetchFromURL(url)
+	resp, err := c.fetchThroughBreaker(ctx, url, prev)
 	if err != nil {
 		log.Printf("error %v fetching %s", err, url)
 	} else {
-		kilos := float64(len(data)) / 1024.0
 		ms := time.Now().Sub(start).Milliseconds()
-		log.Printf("fetched %.1f KB in %d ms from %s", kilos, ms, url)
+		if resp.notModified {
+			// saved all bytes of the resource fetched earlier
+			saved := float64(prev.size) / 1024.0
+			log.Printf("not modified in %d ms from %s (saved %.1f KB)",
+				ms, url, saved)
+		} else {
+			// saved bytes by compression
+			kilos := float64(len(resp.data)) / 1024.0
+			saved := float64(len(resp.data)-resp.size) / 1024.0
+			log.Printf("fetched %.1f KB in %d ms from %s (saved %.1f KB)",
+				kilos, ms, url, saved)
+		}
+	}
+	return resp, err
+}
+
+// fetchQuery fetches earthquakes matching a query (with a resolved time
+// window and optional focus position or bounds) from the FDSN event web
+// service of the USGS. Returns nil data (and no error) when no earthquakes
+// were found.
+func (c *Cache) fetchQuery(ctx context.Context, q earthquakes.Query,
+	start, end int64, pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7) (
+	[]byte, error) {
+
+	url, err := c.resolveQueryURL(q, start, end, pos, bounds)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	resp, err := c.fetchThroughBreaker(ctx, url, resource{})
+	if err != nil {
+		log.Printf("error %v querying %s", err, url)
+		return nil, err
+	}
+	kilos := float64(len(resp.data)) / 1024.0
+	ms := time.Now().Sub(started).Milliseconds()
+	log.Printf("queried %.1f KB in %d ms from %s", kilos, ms, url)
+	return resp.data, nil
+}
+
+// fetchDetail fetches the GeoJSON detail feed for an earthquake
+func (c *Cache) fetchDetail(ctx context.Context, url string) ([]byte, error) {
+	started := time.Now()
+	resp, err := c.fetchThroughBreaker(ctx, url, resource{})
+	if err != nil {
+		log.Printf("error %v fetching %s", err, url)
+		return nil, err
+	}
+	kilos := float64(len(resp.data)) / 1024.0
+	ms := time.Now().Sub(started).Milliseconds()
+	log.Printf("fetched %.1f KB in %d ms from %s", kilos, ms, url)
+	return resp.data, nil
+}
+
+// resolveQueryURL creates an URL to query earthquakes from the FDSN event web
+// service of the USGS (GeoJSON format)
+// (see https://earthquake.usgs.gov/fdsnws/event/1/).
+func (c *Cache) resolveQueryURL(q earthquakes.Query, start, end int64,
+	pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7) (string, error) {
+
+	const timeFormat = "2006-01-02T15:04:05"
+	params := url.Values{}
+	params.Set("format", "geojson")
+	params.Set("orderby", "time")
+	params.Set("starttime", time.Unix(start, 0).UTC().Format(timeFormat))
+	if end != 0 {
+		params.Set("endtime", time.Unix(end, 0).UTC().Format(timeFormat))
+	}
+
+	// minimum of the magnitude range overrides the magnitude filter
+	if q.MinMagnitude != nil {
+		params.Set("minmagnitude", formatFloat(*q.MinMagnitude))
+	} else {
+		switch q.Magnitude {
+		case pb.Magnitude_MAGNITUDE_SIGNIFICANT:
+			params.Set("minsig", strconv.Itoa(significantMin))
+		case pb.Magnitude_MAGNITUDE_M45_PLUS:
+			params.Set("minmagnitude", "4.5")
+		case pb.Magnitude_MAGNITUDE_M25_PLUS:
+			params.Set("minmagnitude", "2.5")
+		case pb.Magnitude_MAGNITUDE_M10_PLUS:
+			params.Set("minmagnitude", "1.0")
+		case pb.Magnitude_MAGNITUDE_ALL:
+		default:
+			return "", ErrUnknownDataRequest
+		}
+	}
+	if q.MaxMagnitude != nil {
+		params.Set("maxmagnitude", formatFloat(*q.MaxMagnitude))
 	}
-	return data, err
+	if q.MinDepth != nil {
+		params.Set("mindepth", formatFloat(*q.MinDepth))
+	}
+	if q.MaxDepth != nil {
+		params.Set("maxdepth", formatFloat(*q.MaxDepth))
+	}
+
+	// bounds (if any) are also applied by the web service, that accepts the
+	// max longitude over 180 for bounds crossing the antimeridian
+	if bounds != nil {
+		maxLon := geolib.LonFromE7(bounds.MaxLongitude)
+		if bounds.MinLongitude > bounds.MaxLongitude {
+			maxLon += 360
+		}
+		params.Set("minlatitude", formatFloat64(geolib.LatFromE7(bounds.MinLatitude)))
+		params.Set("minlongitude", formatFloat64(geolib.LonFromE7(bounds.MinLongitude)))
+		params.Set("maxlatitude", formatFloat64(geolib.LatFromE7(bounds.MaxLatitude)))
+		params.Set("maxlongitude", formatFloat64(maxLon))
+	} else if pos != nil && q.MaxDistance > 0 && !q.IsHypocentral() {
+		// as does the max distance from a focus position (a radius on the
+		// surface that does not limit hypocentral distances)
+		params.Set("latitude", formatFloat64(geolib.LatFromE7(pos.Latitude)))
+		params.Set("longitude", formatFloat64(geolib.LonFromE7(pos.Longitude)))
+		params.Set("maxradiuskm", formatFloat64(q.MaxDistance/1000))
+	}
+
+	return c.baseURL + queryPath + "?" + params.Encode(), nil
+}
+
+func formatFloat(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
+
+func formatFloat64(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
 }
 
 // resolveUrl creates an URL to fetch earthquakes from the GeoJSON Summary
 // data sources from USGS
 // (see https://earthquake.usgs.gov/earthquakes/feed/v1.0/geojson.php).
-func resolveURL(magnitude pb.Magnitude, past pb.Past) (string, error) {
-	var magnID string
-	var pastID string
-	switch magnitude {
-	case pb.Magnitude_MAGNITUDE_SIGNIFICANT:
-		magnID = "significant"
-	case pb.Magnitude_MAGNITUDE_M45_PLUS:
-		magnID = "4.5"
-	case pb.Magnitude_MAGNITUDE_M25_PLUS:
-		magnID = "2.5"
-	case pb.Magnitude_MAGNITUDE_M10_PLUS:
-		magnID = "1.0"
-	case pb.Magnitude_MAGNITUDE_ALL:
-		magnID = "all"
-	}
-	switch past {
-	case pb.Past_PAST_HOUR:
-		pastID = "hour"
-	case pb.Past_PAST_DAY:
-		pastID = "day"
-	case pb.Past_PAST_7DAYS:
-		pastID = "week"
-	case pb.Past_PAST_30DAYS:
-		pastID = "month"
-	}
-
-	if magnID == "" || pastID == "" {
+func (c *Cache) resolveURL(magnitude pb.Magnitude, past pb.Past) (
+	string, error) {
+	magnID, ok1 := feedMagnitudes[magnitude]
+	pastID, ok2 := feedPeriods[past]
+	if !ok1 || !ok2 {
 		return "", ErrUnknownDataRequest
 	}
-	url := apiBaseURL + magnID + "_" + pastID + apiBaseURLPostfix
+	url := c.baseURL + feedPath + magnID + "_" + pastID + feedPostfix
 
 	return url, nil
 }
 
-// fecthFromURL fetches data as []byte from an external HTTP resource
-func fetchFromURL(url string) ([]byte, error) {
+// feedMagnitudes maps magnitudes to ids used on names of summary feeds
+var feedMagnitudes = map[pb.Magnitude]string{
+	pb.Magnitude_MAGNITUDE_SIGNIFICANT: "significant",
+	pb.Magnitude_MAGNITUDE_M45_PLUS:    "4.5",
+	pb.Magnitude_MAGNITUDE_M25_PLUS:    "2.5",
+	pb.Magnitude_MAGNITUDE_M10_PLUS:    "1.0",
+	pb.Magnitude_MAGNITUDE_ALL:         "all",
+}
+
+// feedPeriods maps past periods to ids used on names of summary feeds
+var feedPeriods = map[pb.Past]string{
+	pb.Past_PAST_HOUR:   "hour",
+	pb.Past_PAST_DAY:    "day",
+	pb.Past_PAST_7DAYS:  "week",
+	pb.Past_PAST_30DAYS: "month",
+}
+
+// resolveFeedName resolves a magnitude and a past for a name of a summary feed
+// (like "4.5_day")
+func resolveFeedName(name string) (pb.Magnitude, pb.Past, bool) {
+	for magnitude, magnID := range feedMagnitudes {
+		for past, pastID := range feedPeriods {
+			if name == magnID+"_"+pastID {
+				return magnitude, past, true
+			}
+		}
+	}
+	return pb.Magnitude_MAGNITUDE_UNSPECIFIED, pb.Past_PAST_UNSPECIFIED, false
+}
+
+// fetchFromURL fetches data as []byte from an external HTTP resource (with
+// a conditional request if validators on prev are set, and asking for
+// compressed data)
+func (c *Cache) fetchFromURL(ctx context.Context, url string,
+	prev resource) (*response, error) {
+
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := httpClient.Do(request)
+	request = request.WithContext(ctx)
+	request.Header.Set("Accept-Encoding", "gzip")
+	if prev.etag != "" {
+		request.Header.Set("If-None-Match", prev.etag)
+	}
+	if prev.lastModified != "" {
+		request.Header.Set("If-Modified-Since", prev.lastModified)
+	}
+	resp, err := c.httpClient.Do(request)
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, unavailable(url, err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("resouce %s returned %d", url, resp.StatusCode)
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return &response{
+			notModified: true,
+			maxAge:      parseMaxAge(resp.Header.Get("Cache-Control")),
+			resource:    prev,
+		}, nil
+	case http.StatusNoContent:
+		return &response{}, nil
+	default:
+		return nil, unavailable(url,
+			fmt.Errorf("upstream responded with %d", resp.StatusCode))
 	}
-	data, err := ioutil.ReadAll(resp.Body)
+
+	// read data (decompressing it if needed) and count bytes transferred
+	body := &countingReader{reader: resp.Body}
+	var reader io.Reader = body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, unavailable(url, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	data, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return nil, err
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, unavailable(url, err)
+	}
+
+	return &response{
+		data:   data,
+		maxAge: parseMaxAge(resp.Header.Get("Cache-Control")),
+		resource: resource{
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+			size:         body.count,
+		},
+	}, nil
+}
+
+// unavailable returns an error for an upstream resource not available
+func unavailable(url string, err error) error {
+	return &earthquakes.UpstreamError{
+		URL:    url,
+		Reason: earthquakes.ReasonUnavailable,
+		Err:    err,
 	}
+}
+
+// invalidData returns an error for an upstream resource that responded with
+// data not valid
+func invalidData(url string, err error) error {
+	return &earthquakes.UpstreamError{
+		URL:    url,
+		Reason: earthquakes.ReasonInvalidData,
+		Err:    err,
+	}
+}
+
+// parseMaxAge parses max-age of a Cache-Control header (0 if not present)
+func parseMaxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.HasPrefix(directive, "max-age=") {
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return 0
+}
+
+// countingReader counts bytes read from a reader
+type countingReader struct {
+	reader io.Reader
+	count  int
+}
 
-	return data, nil
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += n
+	return n, err
 }
@@ -0,0 +1,127 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes/usgs/usgstest"
+)
+
+// fake is a local stand-in for the USGS web services used by all tests
+var fake *usgstest.Server
+
+func TestMain(m *testing.M) {
+	// serve fixture files as feeds fetched by tests
+	fake = usgstest.NewServer()
+	for _, feed := range []string{
+		"4.5_day", "2.5_day", "significant_month", "1.0_day", "1.0_week",
+	} {
+		if err := fake.SetFeedFile(feed, "testdata/4.5_day.json"); err != nil {
+			log.Fatal(err)
+		}
+	}
+	// package functions use the default cache
+	WithBaseURL(fake.URL)(defaultCache)
+	code := m.Run()
+	fake.Close()
+	os.Exit(code)
+}
+
+// newTestCache creates a new cache fetching data from the fake
+func newTestCache(opts ...CacheOption) *Cache {
+	return NewCache(append([]CacheOption{WithBaseURL(fake.URL)}, opts...)...)
+}
+
+func TestFetchConditional(t *testing.T) {
+	c := newTestCache()
+	data, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(data)
+	gz.Close()
+
+	const etag = `"v1"`
+	const lastModified = "Thu, 02 Jan 2020 12:27:30 GMT"
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Last-Modified", lastModified)
+			if r.Header.Get("Accept-Encoding") == "gzip" {
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Write(compressed.Bytes())
+			} else {
+				w.Write(data)
+			}
+		}))
+	defer ts.Close()
+
+	// first fetch should get compressed data with validators
+	resp, err := c.fetchFromURL(context.Background(), ts.URL, resource{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.notModified || !bytes.Equal(resp.data, data) {
+		t.Error("invalid data")
+	}
+	if resp.etag != etag || resp.lastModified != lastModified {
+		t.Error("invalid validators")
+	}
+	if resp.size != compressed.Len() {
+		t.Errorf("invalid size %d", resp.size)
+	}
+
+	// conditional fetch should tell data is not modified
+	resp, err = c.fetchFromURL(context.Background(), ts.URL, resp.resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.notModified || resp.data != nil {
+		t.Error("expected not modified")
+	}
+	if resp.etag != etag {
+		t.Error("validators should be kept when not modified")
+	}
+}
+
+func TestFetchLatency(t *testing.T) {
+	// a client timing out before a slow response
+	c := newTestCache(WithHTTPClient(&http.Client{
+		Timeout: 50 * time.Millisecond,
+	}))
+	fake.SetLatency(200 * time.Millisecond)
+	defer fake.SetLatency(0)
+	if _, err := c.fetch(context.Background(), pb.Magnitude_MAGNITUDE_M10_PLUS,
+		pb.Past_PAST_DAY, resource{}); err == nil {
+		t.Error("expected a timeout")
+	}
+
+	// fast enough
+	fake.SetLatency(10 * time.Millisecond)
+	resp, err := c.fetch(context.Background(), pb.Magnitude_MAGNITUDE_M10_PLUS,
+		pb.Past_PAST_DAY, resource{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.data) == 0 || resp.etag == "" {
+		t.Error("invalid response")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"strings"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+// lookup returns an earthquake of a cached collection by the preferred id or
+// any other id associated to it, or nil if not found or nothing cached (the
+// index is built once for each collection cached)
+func (e *entry) lookup(id string) *pb.Earthquake {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.col == nil {
+		return nil
+	}
+	if e.indexed != e.col {
+		e.index = buildIndex(e.col)
+		e.indexed = e.col
+	}
+	return e.index[id]
+}
+
+// buildIndex builds an index from all ids (preferred and associated ones) to
+// earthquakes on a collection
+func buildIndex(col *pb.EarthquakeCollection) map[string]*pb.Earthquake {
+	index := make(map[string]*pb.Earthquake, len(col.Features))
+	for _, eq := range col.Features {
+		if d := eq.Details; d != nil {
+			// ids are like ",ci15296281,us2013mqbd,at00mji9pf,"
+			for _, id := range strings.Split(d.Ids, ",") {
+				if id != "" {
+					if _, exists := index[id]; !exists {
+						index[id] = eq
+					}
+				}
+			}
+		}
+	}
+
+	// preferred ids always override associated ids of other earthquakes
+	for _, eq := range col.Features {
+		index[eq.Id] = eq
+	}
+	return index
+}
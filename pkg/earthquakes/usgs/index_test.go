@@ -0,0 +1,71 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestCacheGetById(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an earthquake with an old id associated to it
+	col.Features[3].Details.Ids = ",old123,us70006tf3,"
+
+	// set a valid collection on the cache for the "30days" list
+	c := newTestCache()
+	entry := c.entries[resolveCacheKey(pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_30DAYS)]
+	entry.mu.Lock()
+	entry.col = col
+	entry.expires = time.Now().Add(time.Minute)
+	entry.mu.Unlock()
+
+	// find by the preferred id and by an old id
+	for _, id := range []string{"us70006tf3", "old123"} {
+		eq, err := c.getById(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if eq.Id != "us70006tf3" {
+			t.Errorf("invalid earthquake for %s", id)
+		}
+	}
+
+	// found on a fresher "hour" list (but not yet on the "30days" list), or
+	// a fresher version on it
+	hour := c.entries[resolveCacheKey(pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_HOUR)]
+	hour.mu.Lock()
+	hour.col = &pb.EarthquakeCollection{Features: []*pb.Earthquake{
+		{Id: "new123", Magnitude: 4.6},
+		{Id: "us70006tf3", Magnitude: 4.7},
+	}}
+	hour.expires = time.Now().Add(time.Minute)
+	hour.mu.Unlock()
+	for id, magnitude := range map[string]float32{"new123": 4.6, "us70006tf3": 4.7} {
+		eq, err := c.getById(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if eq.Id != id || eq.Magnitude != magnitude {
+			t.Errorf("not the fresher earthquake for %s", id)
+		}
+	}
+
+	// not found
+	if _, err := c.getById(context.Background(), "unknown"); err != ErrNotFound {
+		t.Error("expected ErrNotFound")
+	}
+}
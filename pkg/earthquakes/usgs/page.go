@@ -0,0 +1,124 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// pageVersion is a prefix for page tokens (to detect tokens of other formats)
+const pageVersion = "p1"
+
+// order of earthquakes on a list
+type order string
+
+const (
+	// orderFeed as earthquakes are on a feed (not sorted)
+	orderFeed order = ""
+
+	// orderTime by time (newest first)
+	orderTime order = "time"
+
+	// orderDistance by distance to a focus point (nearest first)
+	orderDistance order = "distance"
+
+	// orderHypocentral by hypocentral distance to a focus point (nearest first)
+	orderHypocentral order = "hypocentral"
+)
+
+// resolveOrder resolves a sort key and an order of earthquakes for a query
+// (with focus if not nil). Without a sort key on the query earthquakes are
+// ordered by distance when focusing, by time when paging, otherwise as on a
+// feed. Orders by other sort keys or directions are named by a sort key with
+// a direction (like "magnitude-" for the largest magnitude first).
+func resolveOrder(q earthquakes.Query, focus *pb.GeoPointE7) (pb.SortKey, order) {
+	key, descending := q.SortKey, q.SortDescending
+	if !q.HasSort() {
+		switch {
+		case focus != nil:
+			key = pb.SortKey_SORT_KEY_DISTANCE
+		case q.IsPaging():
+			key, descending = pb.SortKey_SORT_KEY_TIME, true
+		default:
+			return key, orderFeed
+		}
+	}
+	base := order(strings.ToLower(strings.TrimPrefix(key.String(), "SORT_KEY_")))
+	if key == pb.SortKey_SORT_KEY_DISTANCE && q.IsHypocentral() {
+		base = orderHypocentral
+	}
+	switch {
+	case base == orderTime && descending,
+		(base == orderDistance || base == orderHypocentral) && !descending:
+		return key, base
+	case descending:
+		return key, base + "-"
+	default:
+		return key, base + "+"
+	}
+}
+
+// descending returns true if an order has the largest values first
+func (o order) descending() bool {
+	return o == orderTime || strings.HasSuffix(string(o), "-")
+}
+
+// pageKey is a sort key (with tie-breaking id) of an earthquake on a list
+type pageKey struct {
+	order order
+	value float64
+	id    string
+}
+
+// before returns true if k comes before other on a list ordered by k.order
+func (k pageKey) before(other pageKey) bool {
+	return earthquakes.SortBefore(k.value, k.id, other.value, other.id,
+		k.order.descending())
+}
+
+// resolvePageSize returns a page size for a query (clipped to the maximum)
+func resolvePageSize(q earthquakes.Query) int {
+	switch {
+	case q.PageSize <= 0:
+		return defaultPageSize
+	case q.PageSize > maxPageSize:
+		return maxPageSize
+	default:
+		return q.PageSize
+	}
+}
+
+// encodePageToken encodes a sort key of the last earthquake on a page
+func encodePageToken(k pageKey) string {
+	s := strings.Join([]string{pageVersion, string(k.order),
+		strconv.FormatFloat(k.value, 'g', -1, 64), k.id}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// decodePageToken decodes a sort key of the last earthquake on a page
+func decodePageToken(token string) (pageKey, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageKey{}, earthquakes.ErrInvalidPageToken
+	}
+	parts := strings.SplitN(string(data), "|", 4)
+	if len(parts) != 4 || parts[0] != pageVersion {
+		return pageKey{}, earthquakes.ErrInvalidPageToken
+	}
+	value, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return pageKey{}, earthquakes.ErrInvalidPageToken
+	}
+	return pageKey{order: order(parts[1]), value: value, id: parts[3]}, nil
+}
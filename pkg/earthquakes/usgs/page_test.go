@@ -0,0 +1,105 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"io/ioutil"
+	"testing"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+func TestPaging(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPaging(t, col, nil)
+	testPaging(t, col, &pb.GeoPointE7{Latitude: 35_0000000, Longitude: 139_0000000})
+}
+
+func testPaging(t *testing.T, col *pb.EarthquakeCollection, focus *pb.GeoPointE7) {
+	// page through all earthquakes with pages of 5 earthquakes
+	q := earthquakes.Query{PageSize: 5}
+	seen := make(map[string]bool)
+	pages := 0
+	for {
+		page, next, err := copyCollection(col, nil, q, focus, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		if len(page.Features) > 5 {
+			t.Error("too many features")
+		}
+		for _, eq := range page.Features {
+			if seen[eq.Id] {
+				t.Errorf("duplicate %s", eq.Id)
+			}
+			seen[eq.Id] = true
+		}
+		if next == "" {
+			break
+		}
+		q.PageToken = next
+	}
+	if len(seen) != len(col.Features) || pages != 5 {
+		t.Errorf("invalid paging: %d features on %d pages", len(seen), pages)
+	}
+}
+
+func TestPagingStableOnRefresh(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := earthquakes.Query{PageSize: 3}
+	page1, next, err := copyCollection(col, nil, q, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a refreshed collection with a new earthquake that is the newest one
+	newest := &pb.Earthquake{
+		Id:       "new1",
+		Time:     page1.Features[0].Time + 60,
+		Position: &pb.GeoPointE7{},
+	}
+	refreshed := &pb.EarthquakeCollection{
+		Features: append([]*pb.Earthquake{newest}, col.Features...),
+	}
+
+	// the second page should continue after the last one of the first page
+	q.PageToken = next
+	page2, _, err := copyCollection(refreshed, nil, q, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.PageToken = ""
+	q.PageSize = 6
+	both, _, err := copyCollection(col, nil, q, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, eq := range page2.Features {
+		if eq.Id != both.Features[3+i].Id {
+			t.Error("page is not continuing previous page")
+		}
+	}
+
+	// invalid tokens are rejected
+	q.PageToken = "invalid"
+	if _, _, err := copyCollection(col, nil, q, nil, nil, nil); err != earthquakes.ErrInvalidPageToken {
+		t.Error("expected ErrInvalidPageToken")
+	}
+}
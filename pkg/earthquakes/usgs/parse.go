@@ -5,6 +5,7 @@ package usgs
 
 import (
 	"errors"
+	"time"
 
 	pb "github.com/navibyte/quake/api/v1"
 	"github.com/navibyte/quake/internal/geolib"
@@ -129,6 +130,130 @@ func ToEarthquakeCollection(data []byte, details bool) (*pb.EarthquakeCollection
 	return &col, nil
 }
 
+// ToEarthquakeProducts parses products from the GeoJSON detail feed of an
+// earthquake. Only preferred products (first ones for a type) are parsed.
+func ToEarthquakeProducts(data []byte) (*pb.EarthquakeProducts, error) {
+	// validate JSON data
+	if !gjson.ValidBytes(data) {
+		return nil, ErrInvalidJSON
+	}
+
+	// parse JSON data and check that is GeoJSON containing Feature
+	root := gjson.ParseBytes(data)
+	if root.Get("type").String() != "Feature" {
+		return nil, ErrInvalidGeoJSON
+	}
+	c := jsonlib.NewCursor(root)
+
+	// products are on properties grouped by product types
+	var products pb.EarthquakeProducts
+	p := c.Get("properties.products")
+	if prod := p.Get("origin.0"); prod.IsObject() {
+		prop := prod.Get("properties")
+		products.Origin = &pb.OriginProduct{
+			Info: parseProductInfo(prod),
+			Position: &pb.GeoPointE7{
+				Latitude:  geolib.LatToE7(prop.Float64("latitude")),
+				Longitude: geolib.LonToE7(prop.Float64("longitude")),
+				Height:    depthToHeightCentimeters(prop.Float64("depth")),
+			},
+			Time:            parseTime(prop.String("eventtime")),
+			Magnitude:       prop.Float32("magnitude"),
+			MagType:         prop.String("magnitude-type"),
+			HorizontalError: prop.Float32("horizontal-error"),
+			VerticalError:   prop.Float32("vertical-error"),
+			MagnitudeError:  prop.Float32("magnitude-error"),
+			NumStationsUsed: prop.Int32("num-stations-used"),
+			AzimuthalGap:    prop.Float32("azimuthal-gap"),
+			StandardError:   prop.Float32("standard-error"),
+			ReviewStatus:    prop.String("review-status"),
+		}
+	}
+	if prod := p.Get("moment-tensor.0"); prod.IsObject() {
+		prop := prod.Get("properties")
+		products.MomentTensor = &pb.MomentTensorProduct{
+			Info:                 parseProductInfo(prod),
+			DerivedMagnitude:     prop.Float32("derived-magnitude"),
+			DerivedMagnitudeType: prop.String("derived-magnitude-type"),
+			DerivedHeight:        depthToHeightCentimeters(prop.Float64("derived-depth")),
+			ScalarMoment:         prop.Float64("scalar-moment"),
+			TensorMrr:            prop.Float64("tensor-mrr"),
+			TensorMtt:            prop.Float64("tensor-mtt"),
+			TensorMpp:            prop.Float64("tensor-mpp"),
+			TensorMrt:            prop.Float64("tensor-mrt"),
+			TensorMrp:            prop.Float64("tensor-mrp"),
+			TensorMtp:            prop.Float64("tensor-mtp"),
+			PercentDoubleCouple:  prop.Float32("percent-double-couple"),
+			NodalPlane1:          parseNodalPlane(prop, "nodal-plane-1"),
+			NodalPlane2:          parseNodalPlane(prop, "nodal-plane-2"),
+		}
+	}
+	if prod := p.Get("focal-mechanism.0"); prod.IsObject() {
+		prop := prod.Get("properties")
+		products.FocalMechanism = &pb.FocalMechanismProduct{
+			Info:        parseProductInfo(prod),
+			NodalPlane1: parseNodalPlane(prop, "nodal-plane-1"),
+			NodalPlane2: parseNodalPlane(prop, "nodal-plane-2"),
+		}
+	}
+	if prod := p.Get("shakemap.0"); prod.IsObject() {
+		prop := prod.Get("properties")
+		products.Shakemap = &pb.ShakeMapProduct{
+			Info:      parseProductInfo(prod),
+			MaxMmi:    prop.Float32("maxmmi"),
+			MaxPga:    prop.Float32("maxpga"),
+			MaxPgv:    prop.Float32("maxpgv"),
+			MapStatus: prop.String("map-status"),
+		}
+	}
+	if prod := p.Get("losspager.0"); prod.IsObject() {
+		prop := prod.Get("properties")
+		products.Pager = &pb.PagerProduct{
+			Info:   parseProductInfo(prod),
+			Alert:  parseAlert(prop.String("alertlevel")),
+			MaxMmi: prop.Float32("maxmmi"),
+		}
+	}
+	if prod := p.Get("dyfi.0"); prod.IsObject() {
+		prop := prod.Get("properties")
+		products.Dyfi = &pb.DyfiProduct{
+			Info:         parseProductInfo(prod),
+			MaxMmi:       prop.Float32("maxmmi"),
+			NumResponses: prop.Int32("num-responses"),
+		}
+	}
+
+	return &products, nil
+}
+
+func parseProductInfo(prod jsonlib.Cursor) *pb.ProductInfo {
+	return &pb.ProductInfo{
+		Source:      prod.String("source"),
+		Code:        prod.String("code"),
+		UpdatedTime: prod.Int64("updateTime") / 1000,
+		Status:      prod.String("status"),
+	}
+}
+
+func parseNodalPlane(prop jsonlib.Cursor, prefix string) *pb.NodalPlane {
+	if !prop.Get(prefix + "-strike").Exists() {
+		return nil
+	}
+	return &pb.NodalPlane{
+		Strike: prop.Float32(prefix + "-strike"),
+		Dip:    prop.Float32(prefix + "-dip"),
+		Rake:   prop.Float32(prefix + "-rake"),
+	}
+}
+
+func parseTime(value string) int64 {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return 0
+	}
+	return t.Unix()
+}
+
 func depthToHeightCentimeters(depthKM float64) int32 {
 	// Earthquake depth of the event is kilometers, range about [0, 1000].
 	// For this application depth is converted to "height above sea, cm"
@@ -65,3 +65,60 @@ func TestParsingGeoJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestParsingProducts(t *testing.T) {
+	// for parsing we use a locally (on dev environment) stored file
+	b, err := ioutil.ReadFile("testdata/detail.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ensure we can parse products from test GeoJSON data
+	products, err := ToEarthquakeProducts(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	origin := products.Origin
+	if origin == nil {
+		t.Fatal("no origin")
+	}
+	if origin.Info.Source != "us" || origin.Info.UpdatedTime != 1578600000 {
+		t.Error("invalid product info")
+	}
+	if origin.Magnitude != 6.4 || origin.MagType != "mww" ||
+		origin.Position.Latitude != 17_9157000 ||
+		origin.Position.Height != -10_000_00 {
+		t.Error("invalid origin")
+	}
+	if origin.Time != 1578385466 {
+		t.Errorf("invalid origin time %d", origin.Time)
+	}
+	if mt := products.MomentTensor; mt == nil {
+		t.Error("no moment tensor")
+	} else if mt.ScalarMoment != 4.398e+18 || mt.NodalPlane1 == nil ||
+		mt.NodalPlane1.Strike != 268.45 || mt.DerivedHeight != -13_500_00 {
+		t.Error("invalid moment tensor")
+	}
+	if fm := products.FocalMechanism; fm == nil || fm.NodalPlane2 == nil ||
+		fm.NodalPlane2.Rake != -85 {
+		t.Error("invalid focal mechanism")
+	}
+	if sm := products.Shakemap; sm == nil || sm.MapStatus != "RELEASED" {
+		t.Error("invalid shakemap")
+	}
+	if pager := products.Pager; pager == nil || pager.Alert != pb.Alert_ALERT_ORANGE {
+		t.Error("invalid pager")
+	}
+	if dyfi := products.Dyfi; dyfi == nil || dyfi.NumResponses != 12000 {
+		t.Error("invalid dyfi")
+	}
+
+	// summary format is not a detail feed
+	b, err = ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ToEarthquakeProducts(b); err != ErrInvalidGeoJSON {
+		t.Error("expected ErrInvalidGeoJSON")
+	}
+}
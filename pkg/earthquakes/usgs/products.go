@@ -0,0 +1,180 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+const (
+	// productsMaxAge is a time to live for products cached for an earthquake
+	productsMaxAge = 10 * time.Minute
+
+	// maxProductEntries is a maximum number of earthquakes with products
+	// cached (the least recently used ones are evicted)
+	maxProductEntries = 1000
+)
+
+// productEntry for caching products fetched&parsed for an earthquake
+type productEntry struct {
+	mu       sync.Mutex
+	id       string
+	products *pb.EarthquakeProducts
+	expires  time.Time
+
+	// refresh in flight (nil if no refresh is active)
+	refreshing *productRefresh
+}
+
+// productRefresh is an active refresh of products, done closed when finished
+type productRefresh struct {
+	done     chan struct{}
+	products *pb.EarthquakeProducts
+	err      error
+}
+
+// productCache caches products for earthquakes
+type productCache struct {
+	// product entries identified by preferred ids of earthquakes on a LRU
+	// list, the most recently used first (access to the map and the list is
+	// synchronized by one mutex, and access to each entry by a mutex for an
+	// entry)
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// newProductCache creates a new empty cache for products
+func newProductCache() *productCache {
+	return &productCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// GetEarthquakeProducts returns products from the detail feed for an
+// earthquake identified by the preferred id or any other id associated to it.
+func GetEarthquakeProducts(ctx context.Context, id string) (
+	*pb.EarthquakeProducts, error) {
+	return defaultCache.getProducts(ctx, id)
+}
+
+// getProducts returns products for an earthquake found on the cache by id
+func (c *Cache) getProducts(ctx context.Context, id string) (
+	*pb.EarthquakeProducts, error) {
+
+	eq, err := c.getById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.cacheGetProducts(ctx, eq)
+}
+
+// cacheGetProducts returns products for an earthquake (cached or fetched from
+// the detail feed if no cache hit), or an error of the context if it's done
+// before fetched products are available (a fetch shared by other callers is
+// not canceled)
+func (c *Cache) cacheGetProducts(ctx context.Context, eq *pb.Earthquake) (
+	*pb.EarthquakeProducts, error) {
+
+	if eq.Details == nil || eq.Details.DetailFeedUrl == "" {
+		// no detail feed, so no products either
+		return &pb.EarthquakeProducts{}, nil
+	}
+	entry := c.products.resolve(eq.Id)
+
+	// synchronize access to an entry (however fetching and parsing products is
+	// done on a refresh without holding the lock)
+	entry.mu.Lock()
+
+	// return cached products if available and not yet expired
+	if entry.products != nil && time.Now().Before(entry.expires) {
+		products := entry.products
+		entry.mu.Unlock()
+		return products, nil
+	}
+
+	// need to wait for a refresh (unless the caller gives up, however the
+	// refresh continues for other callers)
+	r := entry.startRefresh(c, eq.Details.DetailFeedUrl)
+	entry.mu.Unlock()
+	select {
+	case <-r.done:
+		return r.products, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// startRefresh starts a refresh for an entry unless already refreshing and
+// returns the active refresh (must be called when holding a lock)
+func (e *productEntry) startRefresh(c *Cache, url string) *productRefresh {
+	if e.refreshing != nil {
+		return e.refreshing
+	}
+
+	// a refresh is shared by callers, so it's not canceled by any of them,
+	// but limited by a timeout
+	r := &productRefresh{done: make(chan struct{})}
+	e.refreshing = r
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		products, err := c.fetchProducts(ctx, url)
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		defer close(r.done)
+		e.refreshing = nil
+		if err == nil {
+			e.products = products
+			e.expires = time.Now().Add(productsMaxAge)
+		}
+		r.products, r.err = products, err
+	}()
+	return r
+}
+
+// fetchProducts fetches and parses products from the detail feed of an URL
+func (c *Cache) fetchProducts(ctx context.Context, url string) (
+	*pb.EarthquakeProducts, error) {
+
+	data, err := c.fetchDetail(ctx, url)
+	if err != nil {
+		if ctx.Err() != nil {
+			// a refresh timed out (that is not a timeout of a caller)
+			err = unavailable(url, err)
+		}
+		return nil, err
+	}
+	products, err := ToEarthquakeProducts(data)
+	if err != nil {
+		return nil, invalidData(url, err)
+	}
+	return products, nil
+}
+
+// resolve returns an entry for an id (created if not existing, evicting the
+// least recently used entry if there are too many of them)
+func (p *productCache) resolve(id string) *productEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if elem, ok := p.entries[id]; ok {
+		p.lru.MoveToFront(elem)
+		return elem.Value.(*productEntry)
+	}
+	entry := &productEntry{id: id}
+	p.entries[id] = p.lru.PushFront(entry)
+	if p.lru.Len() > maxProductEntries {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.entries, oldest.Value.(*productEntry).id)
+	}
+	return entry
+}
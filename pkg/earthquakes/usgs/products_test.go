@@ -0,0 +1,87 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestCacheGetProducts(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/detail.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			time.Sleep(100 * time.Millisecond)
+			w.Write(data)
+		}))
+	defer ts.Close()
+	c := newTestCache()
+	eq := &pb.Earthquake{
+		Id:      "us70006tf3",
+		Details: &pb.EarthquakeDetails{DetailFeedUrl: ts.URL},
+	}
+
+	// a caller giving up does not cancel a fetch shared by other callers
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.cacheGetProducts(ctx, eq); err != context.DeadlineExceeded {
+		t.Errorf("expected a deadline exceeded, got %v", err)
+	}
+	products, err := c.cacheGetProducts(context.Background(), eq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if products.Origin == nil {
+		t.Error("invalid products")
+	}
+
+	// then products are cached
+	if _, err := c.cacheGetProducts(context.Background(), eq); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("invalid request count %d", n)
+	}
+}
+
+func TestProductCacheResolve(t *testing.T) {
+	p := newProductCache()
+	first := p.resolve("first")
+
+	// fill the cache up, accessing the first entry now and then
+	for i := 0; i < maxProductEntries*2; i++ {
+		p.resolve(fmt.Sprintf("id%d", i))
+		if i%100 == 0 {
+			if p.resolve("first") != first {
+				t.Fatalf("recently used entry evicted")
+			}
+		}
+	}
+
+	// the cache is bounded and the least recently used entries are evicted
+	n, m := p.lru.Len(), len(p.entries)
+	_, present := p.entries["id0"]
+	if n != maxProductEntries || m != maxProductEntries {
+		t.Errorf("cache size %d/%d, expected %d", n, m, maxProductEntries)
+	}
+	if present {
+		t.Errorf("least recently used entry not evicted")
+	}
+	if p.resolve("first") != first {
+		t.Errorf("recently used entry evicted")
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/internal/mathlib"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// ErrInvalidXML can be returned by the QuakeML parser
+var ErrInvalidXML = errors.New("invalid XML data")
+
+// ErrInvalidQuakeML can be returned by the QuakeML parser
+var ErrInvalidQuakeML = errors.New("XML data is not valid QuakeML")
+
+// QuakeML 1.2 (BED) structures. Only elements needed by the domain model are
+// mapped, namespaces are not checked so that documents by different agencies
+// (USGS, EMSC, GeoNet, INGV, ISC) can be parsed the same way.
+
+type quakeML struct {
+	XMLName         xml.Name            `xml:"quakeml"`
+	EventParameters *qmlEventParameters `xml:"eventParameters"`
+}
+
+type qmlEventParameters struct {
+	PublicID     string          `xml:"publicID,attr"`
+	CreationInfo qmlCreationInfo `xml:"creationInfo"`
+	Events       []qmlEvent      `xml:"event"`
+}
+
+type qmlEvent struct {
+	PublicID             string           `xml:"publicID,attr"`
+	EventSource          string           `xml:"eventsource,attr"`
+	EventID              string           `xml:"eventid,attr"`
+	PreferredOriginID    string           `xml:"preferredOriginID"`
+	PreferredMagnitudeID string           `xml:"preferredMagnitudeID"`
+	Type                 string           `xml:"type"`
+	Descriptions         []qmlDescription `xml:"description"`
+	CreationInfo         qmlCreationInfo  `xml:"creationInfo"`
+	Origins              []qmlOrigin      `xml:"origin"`
+	Magnitudes           []qmlMagnitude   `xml:"magnitude"`
+}
+
+type qmlDescription struct {
+	Text string `xml:"text"`
+	Type string `xml:"type"`
+}
+
+type qmlCreationInfo struct {
+	AgencyID     string `xml:"agencyID"`
+	CreationTime string `xml:"creationTime"`
+}
+
+type qmlTimeQuantity struct {
+	Value       string  `xml:"value"`
+	Uncertainty float64 `xml:"uncertainty"`
+}
+
+type qmlRealQuantity struct {
+	Value       float64 `xml:"value"`
+	Uncertainty float64 `xml:"uncertainty"`
+}
+
+type qmlOrigin struct {
+	PublicID          string               `xml:"publicID,attr"`
+	Time              qmlTimeQuantity      `xml:"time"`
+	Latitude          qmlRealQuantity      `xml:"latitude"`
+	Longitude         qmlRealQuantity      `xml:"longitude"`
+	Depth             qmlRealQuantity      `xml:"depth"`
+	Quality           qmlOriginQuality     `xml:"quality"`
+	OriginUncertainty qmlOriginUncertainty `xml:"originUncertainty"`
+	EvaluationMode    string               `xml:"evaluationMode"`
+	EvaluationStatus  string               `xml:"evaluationStatus"`
+	CreationInfo      qmlCreationInfo      `xml:"creationInfo"`
+}
+
+type qmlOriginQuality struct {
+	UsedStationCount int32   `xml:"usedStationCount"`
+	StandardError    float32 `xml:"standardError"`
+	AzimuthalGap     float32 `xml:"azimuthalGap"`
+	MinimumDistance  float32 `xml:"minimumDistance"`
+}
+
+type qmlOriginUncertainty struct {
+	HorizontalUncertainty float64 `xml:"horizontalUncertainty"`
+}
+
+type qmlMagnitude struct {
+	PublicID     string          `xml:"publicID,attr"`
+	Mag          qmlRealQuantity `xml:"mag"`
+	Type         string          `xml:"type"`
+	CreationInfo qmlCreationInfo `xml:"creationInfo"`
+}
+
+// ToEarthquakeCollectionFromQuakeML parses QuakeML 1.2 data to earthquake
+// objects. Positions and magnitudes are taken from preferred origins and
+// magnitudes (or first ones if preferred are not set) of events.
+func ToEarthquakeCollectionFromQuakeML(data []byte, details bool) (*pb.EarthquakeCollection, error) {
+	// parse XML data and check that is QuakeML containing event parameters
+	var doc quakeML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		if _, ok := err.(xml.UnmarshalError); ok {
+			return nil, ErrInvalidQuakeML
+		}
+		return nil, ErrInvalidXML
+	}
+	params := doc.EventParameters
+	if params == nil {
+		return nil, ErrInvalidQuakeML
+	}
+
+	// init response with metadata
+	var col pb.EarthquakeCollection
+	col.Metadata = &pb.EarthquakeMetadata{
+		GeneratedTime: parseQuakeMLTime(params.CreationInfo.CreationTime),
+		Url:           params.PublicID,
+	}
+
+	// parse events (that is earthquakes)
+	for i := range params.Events {
+		event := &params.Events[i]
+		origin := event.preferredOrigin()
+		if origin == nil {
+			continue // ignore events without any origin
+		}
+		mag := event.preferredMagnitude()
+
+		// parse an Earthquake from a QuakeML event structure
+		var eq pb.Earthquake
+		eq.Id = event.id()
+		// QuakeML depth is meters, height (above sea level) is centimeters
+		eq.Position = &pb.GeoPointE7{
+			Latitude:  geolib.LatToE7(origin.Latitude.Value),
+			Longitude: geolib.LonToE7(origin.Longitude.Value),
+			Height:    mathlib.Round32(-origin.Depth.Value * 100),
+		}
+		if mag != nil {
+			eq.Magnitude = float32(mag.Mag.Value)
+		}
+		eq.Place = event.place()
+		eq.Time = parseQuakeMLTime(origin.Time.Value)
+		eq.UpdatedTime = event.updatedTime(origin, mag)
+
+		// detailed properties are parsed only if needed
+		if details {
+			eq.Details = &pb.EarthquakeDetails{
+				Id:              eq.Id,
+				Status:          parseEvaluation(origin.EvaluationMode, origin.EvaluationStatus),
+				Network:         event.network(origin),
+				Code:            event.code(),
+				Ids:             "," + eq.Id + ",",
+				Nst:             origin.Quality.UsedStationCount,
+				Dmin:            origin.Quality.MinimumDistance,
+				Rms:             origin.Quality.StandardError,
+				Gap:             origin.Quality.AzimuthalGap,
+				Type:            parseEventType(event.Type),
+				HorizontalError: float32(origin.OriginUncertainty.HorizontalUncertainty / 1000),
+				DepthError:      float32(origin.Depth.Uncertainty / 1000),
+				TimeError:       float32(origin.Time.Uncertainty),
+			}
+			if network := eq.Details.Network; network != "" {
+				eq.Details.Sources = "," + network + ","
+			}
+			if mag != nil {
+				eq.Details.MagType = mag.Type
+				eq.Details.MagnitudeError = float32(mag.Mag.Uncertainty)
+			}
+		}
+
+		// append a new Earthquake to collection
+		col.Features = append(col.Features, &eq)
+	}
+	col.Metadata.Count = int32(len(col.Features))
+
+	// bounds are not available on QuakeML, so calculate them
+	col.Bounds = earthquakes.BoundsOf(col.Features)
+
+	return &col, nil
+}
+
+func (e *qmlEvent) preferredOrigin() *qmlOrigin {
+	for i := range e.Origins {
+		if e.Origins[i].PublicID == e.PreferredOriginID {
+			return &e.Origins[i]
+		}
+	}
+	if len(e.Origins) > 0 {
+		return &e.Origins[0]
+	}
+	return nil
+}
+
+func (e *qmlEvent) preferredMagnitude() *qmlMagnitude {
+	for i := range e.Magnitudes {
+		if e.Magnitudes[i].PublicID == e.PreferredMagnitudeID {
+			return &e.Magnitudes[i]
+		}
+	}
+	if len(e.Magnitudes) > 0 {
+		return &e.Magnitudes[0]
+	}
+	return nil
+}
+
+func (e *qmlEvent) id() string {
+	// USGS publishes ids with "catalog" attributes as on GeoJSON
+	if e.EventSource != "" && e.EventID != "" {
+		return e.EventSource + e.EventID
+	}
+	// others use resource identifiers like "smi:www.emsc-csem.org/event/123"
+	// or "smi:webservices.ingv.it/fdsnws/event/1/query?eventId=123"
+	return e.code()
+}
+
+func (e *qmlEvent) code() string {
+	if e.EventID != "" {
+		return e.EventID
+	}
+	return e.PublicID[strings.LastIndexAny(e.PublicID, "/=")+1:]
+}
+
+func (e *qmlEvent) network(origin *qmlOrigin) string {
+	switch {
+	case e.EventSource != "":
+		return e.EventSource
+	case e.CreationInfo.AgencyID != "":
+		return e.CreationInfo.AgencyID
+	default:
+		return origin.CreationInfo.AgencyID
+	}
+}
+
+func (e *qmlEvent) place() string {
+	for _, desc := range e.Descriptions {
+		if desc.Type == "earthquake name" || desc.Type == "region name" {
+			return desc.Text
+		}
+	}
+	if len(e.Descriptions) > 0 {
+		return e.Descriptions[0].Text
+	}
+	return ""
+}
+
+func (e *qmlEvent) updatedTime(origin *qmlOrigin, mag *qmlMagnitude) int64 {
+	// the latest creation time of the event, its preferred origin or magnitude
+	updated := parseQuakeMLTime(e.CreationInfo.CreationTime)
+	updated = mathlib.MaxInt64(updated, parseQuakeMLTime(origin.CreationInfo.CreationTime))
+	if mag != nil {
+		updated = mathlib.MaxInt64(updated, parseQuakeMLTime(mag.CreationInfo.CreationTime))
+	}
+	return updated
+}
+
+func parseQuakeMLTime(value string) int64 {
+	// QuakeML times are UTC, but some agencies omit the time zone designator
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02T15:04:05.999999999", value)
+		if err != nil {
+			return 0
+		}
+	}
+	return t.Unix()
+}
+
+func parseEvaluation(mode, status string) pb.Status {
+	switch {
+	case status == "rejected":
+		return pb.Status_STATUS_DELETED
+	case mode == "manual", status == "reviewed", status == "final", status == "confirmed":
+		return pb.Status_STATUS_REVIEWED
+	case mode == "automatic", status == "preliminary":
+		return pb.Status_STATUS_AUTOMATIC
+	default:
+		return pb.Status_STATUS_UNSPECIFIED
+	}
+}
+
+func parseEventType(value string) pb.Type {
+	// QuakeML types are like "earthquake" or "quarry blast"
+	if value == "quarry blast" {
+		return pb.Type_TYPE_QUARRY
+	}
+	return parseType(value)
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"io/ioutil"
+	"testing"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestParsingQuakeMLFromUSGS(t *testing.T) {
+	// for parsing we use a locally (on dev environment) stored file
+	b, err := ioutil.ReadFile("testdata/usgs.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ensure we can parse test QuakeML data to earthquakes
+	col, err := ToEarthquakeCollectionFromQuakeML(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Metadata.GeneratedTime != 1578643200 || col.Metadata.Count != 1 {
+		t.Error("invalid metadata")
+	}
+	if len(col.Features) != 1 {
+		t.Fatal("invalid feature count")
+	}
+	eq := col.Features[0]
+	if eq.Id != "us70006vll" || eq.Magnitude != 6.4 ||
+		eq.Place != "8km S of Indios, Puerto Rico" ||
+		eq.Time != 1578385466 || eq.UpdatedTime != 1578600000 {
+		t.Error("invalid earthquake")
+	}
+	if pos := eq.Position; pos.Latitude != 17_9157000 ||
+		pos.Longitude != -66_8113000 || pos.Height != -10_000_00 {
+		t.Error("invalid position")
+	}
+	det := eq.Details
+	if det == nil {
+		t.Fatal("details is nil")
+	}
+	if det.Network != "us" || det.Code != "70006vll" ||
+		det.Ids != ",us70006vll," || det.Sources != ",us," ||
+		det.MagType != "mww" || det.Status != pb.Status_STATUS_REVIEWED ||
+		det.Type != pb.Type_TYPE_EARTHQUAKE {
+		t.Error("invalid details")
+	}
+	if det.Nst != 132 || det.Gap != 16 || det.Rms != 0.95 || det.Dmin != 0.087 {
+		t.Error("invalid origin quality")
+	}
+	if det.HorizontalError != 4.6 || det.DepthError != 1.8 ||
+		det.MagnitudeError != 0.039 || det.TimeError != 0 {
+		t.Error("invalid uncertainties")
+	}
+}
+
+func TestParsingQuakeMLFromEMSC(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/emsc.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollectionFromQuakeML(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an event without any origin is ignored
+	if len(col.Features) != 2 || col.Metadata.Count != 2 {
+		t.Fatal("invalid feature count")
+	}
+
+	// preferred origin and magnitude, time without a time zone designator
+	eq := col.Features[0]
+	if eq.Id != "20200107_0000030" || eq.Magnitude != 6.5 ||
+		eq.Place != "PUERTO RICO REGION" || eq.Time != 1578385466 ||
+		eq.UpdatedTime != 1578388200 {
+		t.Error("invalid earthquake")
+	}
+	if pos := eq.Position; pos.Latitude != 17_8600000 ||
+		pos.Longitude != -66_8200000 || pos.Height != -6_000_00 {
+		t.Error("invalid position")
+	}
+	det := eq.Details
+	if det.Network != "EMSC" || det.Status != pb.Status_STATUS_REVIEWED ||
+		det.MagType != "mw" || det.Nst != 98 || det.Gap != 54 {
+		t.Error("invalid details")
+	}
+	if det.HorizontalError != 5 || det.DepthError != 3 ||
+		det.MagnitudeError != 0.1 || det.TimeError != 0.5 {
+		t.Error("invalid uncertainties")
+	}
+
+	// first origin and magnitude when no preferred ones
+	eq = col.Features[1]
+	if eq.Id != "20200107_0000054" || eq.Magnitude != 2.1 ||
+		eq.Details.Network != "NOA" ||
+		eq.Details.Status != pb.Status_STATUS_AUTOMATIC ||
+		eq.Details.Type != pb.Type_TYPE_QUARRY {
+		t.Error("invalid second earthquake")
+	}
+
+	// bounds are calculated from positions
+	if bounds := col.Bounds; bounds == nil ||
+		bounds.MinLatitude != 17_8600000 || bounds.MaxLatitude != 35_3000000 ||
+		bounds.MinLongitude != -66_8200000 || bounds.MaxLongitude != 25_1000000 ||
+		bounds.MinHeight != -6_000_00 || bounds.MaxHeight != -2_000_00 {
+		t.Error("invalid bounds")
+	}
+
+	// without details
+	col, err = ToEarthquakeCollectionFromQuakeML(b, false)
+	if err != nil || col.Features[0].Details != nil {
+		t.Error("details should not be parsed")
+	}
+}
+
+func TestParsingInvalidQuakeML(t *testing.T) {
+	if _, err := ToEarthquakeCollectionFromQuakeML([]byte("<quakeml"), true); err != ErrInvalidXML {
+		t.Errorf("expected ErrInvalidXML, got %v", err)
+	}
+	if _, err := ToEarthquakeCollectionFromQuakeML([]byte("<html></html>"), true); err != ErrInvalidQuakeML {
+		t.Errorf("expected ErrInvalidQuakeML, got %v", err)
+	}
+	if _, err := ToEarthquakeCollectionFromQuakeML([]byte("<quakeml></quakeml>"), true); err != ErrInvalidQuakeML {
+		t.Errorf("expected ErrInvalidQuakeML, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// feedThresholds are minimum magnitudes of feeds from the smallest feed to
+// the largest one (the "all" feed has no threshold)
+var feedThresholds = []struct {
+	magnitude pb.Magnitude
+	min       float32
+}{
+	{pb.Magnitude_MAGNITUDE_M45_PLUS, 4.5},
+	{pb.Magnitude_MAGNITUDE_M25_PLUS, 2.5},
+	{pb.Magnitude_MAGNITUDE_M10_PLUS, 1.0},
+}
+
+// feedPasts are periods of feeds from the shortest to the longest one
+var feedPasts = []pb.Past{
+	pb.Past_PAST_HOUR,
+	pb.Past_PAST_DAY,
+	pb.Past_PAST_7DAYS,
+	pb.Past_PAST_30DAYS,
+}
+
+// queryFilter filters earthquakes by a time window, a magnitude range and a
+// depth range
+type queryFilter struct {
+	start, end         int64
+	min, max           *float32
+	minDepth, maxDepth *float32
+}
+
+// newQueryFilter returns a filter for a query or nil if no filter is needed
+func newQueryFilter(q earthquakes.Query, now time.Time) *queryFilter {
+	if !q.HasWindow() && !q.HasMagnitudeRange() && !q.HasDepthRange() {
+		return nil
+	}
+	f := &queryFilter{
+		min:      q.MinMagnitude,
+		max:      q.MaxMagnitude,
+		minDepth: q.MinDepth,
+		maxDepth: q.MaxDepth,
+	}
+	if q.HasWindow() {
+		f.start, f.end = q.Window(now)
+	}
+	return f
+}
+
+// match returns true if an earthquake is accepted by the filter
+func (f *queryFilter) match(eq *pb.Earthquake) bool {
+	if f.start != 0 && eq.Time < f.start {
+		return false
+	}
+	if f.end != 0 && eq.Time > f.end {
+		return false
+	}
+	if f.min != nil && eq.Magnitude < *f.min {
+		return false
+	}
+	if f.max != nil && eq.Magnitude > *f.max {
+		return false
+	}
+	if f.minDepth != nil || f.maxDepth != nil {
+		return earthquakes.InDepthRange(eq.Position, f.minDepth, f.maxDepth)
+	}
+	return true
+}
+
+// resolveFeed resolves the smallest cached feed (as magnitude and past) that
+// contains all earthquakes matching a query, returns false if the query does
+// not fit in any feed
+func resolveFeed(q earthquakes.Query, now time.Time) (
+	pb.Magnitude, pb.Past, bool) {
+
+	// resolve a magnitude feed (minimum of the range overrides magnitude)
+	magnitude := q.Magnitude
+	if q.MinMagnitude != nil {
+		magnitude = pb.Magnitude_MAGNITUDE_ALL
+		for _, th := range feedThresholds {
+			if th.min <= *q.MinMagnitude {
+				magnitude = th.magnitude
+				break
+			}
+		}
+	}
+
+	// without window the past of the query is used
+	if !q.HasWindow() {
+		return magnitude, q.Past, true
+	}
+
+	// resolve the shortest past period that covers the window start
+	start, _ := q.Window(now)
+	for _, past := range feedPasts {
+		if start >= now.Add(-earthquakes.PastPeriod(past)).Unix() {
+			return magnitude, past, true
+		}
+	}
+	return magnitude, q.Past, false
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+func TestResolveFeed(t *testing.T) {
+	now := time.Now()
+	min := float32(3.0)
+	tests := []struct {
+		q         earthquakes.Query
+		magnitude pb.Magnitude
+		past      pb.Past
+		ok        bool
+	}{
+		// no window nor range, so the feed of the query
+		{earthquakes.Query{
+			Magnitude: pb.Magnitude_MAGNITUDE_SIGNIFICANT,
+			Past:      pb.Past_PAST_7DAYS,
+		}, pb.Magnitude_MAGNITUDE_SIGNIFICANT, pb.Past_PAST_7DAYS, true},
+		// minimum magnitude resolves the feed for M2.5+
+		{earthquakes.Query{
+			Magnitude:    pb.Magnitude_MAGNITUDE_M45_PLUS,
+			Past:         pb.Past_PAST_DAY,
+			MinMagnitude: &min,
+		}, pb.Magnitude_MAGNITUDE_M25_PLUS, pb.Past_PAST_DAY, true},
+		// window of past 3 hours fits in the day feed
+		{earthquakes.Query{
+			Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+			StartTime: now.Add(-3 * time.Hour).Unix(),
+		}, pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_DAY, true},
+		// window starting 60 days ago does not fit in any feed
+		{earthquakes.Query{
+			Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+			StartTime: now.Add(-60 * 24 * time.Hour).Unix(),
+			EndTime:   now.Add(-40 * 24 * time.Hour).Unix(),
+		}, pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_UNSPECIFIED, false},
+	}
+	for i, test := range tests {
+		magnitude, past, ok := resolveFeed(test.q, now)
+		if ok != test.ok {
+			t.Errorf("test %d: invalid fit", i)
+		}
+		if ok && (magnitude != test.magnitude || past != test.past) {
+			t.Errorf("test %d: invalid feed %v %v", i, magnitude, past)
+		}
+	}
+}
+
+func TestQueryFilter(t *testing.T) {
+	now := time.Now()
+	if f := newQueryFilter(earthquakes.Query{}, now); f != nil {
+		t.Error("no filter expected")
+	}
+	min, max := float32(2.0), float32(4.0)
+	f := newQueryFilter(earthquakes.Query{
+		StartTime:    1000,
+		EndTime:      2000,
+		MinMagnitude: &min,
+		MaxMagnitude: &max,
+	}, now)
+	accept := &pb.Earthquake{Time: 1500, Magnitude: 3.0}
+	if !f.match(accept) {
+		t.Error("should match")
+	}
+	for _, eq := range []*pb.Earthquake{
+		{Time: 999, Magnitude: 3.0},
+		{Time: 2001, Magnitude: 3.0},
+		{Time: 1500, Magnitude: 1.9},
+		{Time: 1500, Magnitude: 4.1},
+	} {
+		if f.match(eq) {
+			t.Errorf("should not match %v", eq)
+		}
+	}
+
+	// depths are kilometers, heights of positions centimeters
+	minDepth, maxDepth := float32(10.0), float32(70.0)
+	f = newQueryFilter(earthquakes.Query{
+		MinDepth: &minDepth,
+		MaxDepth: &maxDepth,
+	}, now)
+	for height, match := range map[int32]bool{
+		-10_000_00: true,
+		-70_000_00: true,
+		-9_990_00:  false,
+		-70_010_00: false,
+		0:          false,
+	} {
+		eq := &pb.Earthquake{Position: &pb.GeoPointE7{Height: height}}
+		if f.match(eq) != match {
+			t.Errorf("invalid match %v for height %d", !match, height)
+		}
+	}
+}
+
+func TestResolveQueryURL(t *testing.T) {
+	max := float32(5.5)
+	q := earthquakes.Query{
+		Magnitude:    pb.Magnitude_MAGNITUDE_M25_PLUS,
+		MaxMagnitude: &max,
+	}
+	bounds := &pb.GeoBoundsE7{
+		MinLatitude:  10_0000000,
+		MinLongitude: -70_0000000,
+		MaxLatitude:  20_0000000,
+		MaxLongitude: -60_5000000,
+	}
+	c := newTestCache()
+	s, err := c.resolveQueryURL(q, 1577836800, 1578441600, nil, bounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := u.Query()
+	for key, value := range map[string]string{
+		"format":       "geojson",
+		"starttime":    "2020-01-01T00:00:00",
+		"endtime":      "2020-01-08T00:00:00",
+		"minmagnitude": "2.5",
+		"maxmagnitude": "5.5",
+		"minlatitude":  "10",
+		"maxlongitude": "-60.5",
+	} {
+		if params.Get(key) != value {
+			t.Errorf("invalid %s: %s", key, params.Get(key))
+		}
+	}
+
+	// bounds crossing the antimeridian have the max longitude over 180
+	bounds.MinLongitude, bounds.MaxLongitude = 170_0000000, -170_0000000
+	s, err = c.resolveQueryURL(q, 1577836800, 1578441600, nil, bounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, _ = url.Parse(s); u.Query().Get("minlongitude") != "170" ||
+		u.Query().Get("maxlongitude") != "190" {
+		t.Errorf("invalid longitudes crossing the antimeridian: %s", s)
+	}
+
+	// max distance from a focus position is queried as a radius
+	q.MaxDistance = 250_000
+	pos := &pb.GeoPointE7{Latitude: 17_9000000, Longitude: -66_8000000}
+	s, err = c.resolveQueryURL(q, 1577836800, 1578441600, pos, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, _ = url.Parse(s); u.Query().Get("latitude") != "17.9" ||
+		u.Query().Get("longitude") != "-66.8" ||
+		u.Query().Get("maxradiuskm") != "250" {
+		t.Errorf("invalid radius query: %s", s)
+	}
+
+	// but not for hypocentral distances, and depths are queried as is
+	q.DistanceMetric = pb.DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL
+	minDepth := float32(70)
+	q.MinDepth = &minDepth
+	s, err = c.resolveQueryURL(q, 1577836800, 1578441600, pos, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, _ = url.Parse(s); u.Query().Get("maxradiuskm") != "" ||
+		u.Query().Get("mindepth") != "70" || u.Query().Get("maxdepth") != "" {
+		t.Errorf("invalid hypocentral query: %s", s)
+	}
+
+	// unknown magnitude should fail
+	if _, err := c.resolveQueryURL(earthquakes.Query{}, 0, 0, nil, nil); err != ErrUnknownDataRequest {
+		t.Error("expected ErrUnknownDataRequest")
+	}
+}
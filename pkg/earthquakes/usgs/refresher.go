@@ -0,0 +1,70 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"time"
+)
+
+const (
+	// refresherInterval is an interval to check entries needing a refresh
+	refresherInterval = 15 * time.Second
+
+	// hotPeriod is a period after the latest request that an entry is hot
+	hotPeriod = 30 * time.Minute
+
+	// refreshAheadDivisor defines when hot entries are refreshed before
+	// expiry (as a part of max age, 5 meaning the last fifth of max age)
+	refreshAheadDivisor = 5
+)
+
+// StartRefresher starts a background scheduler that refreshes hot entries
+// (requested recently) of the default cache before they expire, so that
+// requests are served from the cache without waiting for fetches. Returns a
+// function stopping it.
+func StartRefresher() (stop func()) {
+	return defaultCache.StartRefresher()
+}
+
+// StartRefresher starts a background scheduler that refreshes hot entries
+// (requested recently) of the cache before they expire. Returns a function
+// stopping it.
+func (c *Cache) StartRefresher() (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(refresherInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				c.refreshHotEntries(now)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// refreshHotEntries starts refreshes for hot entries expiring soon
+func (c *Cache) refreshHotEntries(now time.Time) {
+	for _, entry := range c.entries {
+		entry.mu.Lock()
+		if entry.needsRefreshAhead(now) {
+			entry.startRefresh()
+		}
+		entry.mu.Unlock()
+	}
+}
+
+// needsRefreshAhead returns true if an entry is hot and is expiring soon (must
+// be called when holding a lock), derived entries are never refreshed
+func (e *entry) needsRefreshAhead(now time.Time) bool {
+	if e.col == nil || e.source != nil || e.refreshing != nil ||
+		now.Sub(e.lastRequest) > hotPeriod {
+		return false
+	}
+	ahead := e.maxAge() / refreshAheadDivisor
+	return e.expires.Sub(now) < ahead
+}
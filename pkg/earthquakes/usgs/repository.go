@@ -4,75 +4,218 @@
 package usgs
 
 import (
+	"context"
 	"sort"
+	"time"
 
 	pb "github.com/navibyte/quake/api/v1"
 	"github.com/navibyte/quake/internal/geolib"
-	"github.com/navibyte/quake/internal/mathlib"
+	"github.com/navibyte/quake/pkg/earthquakes"
 )
 
-func GetEarthquake(id string) (*pb.Earthquake, error) {
-	return cacheGetById(id)
+// Repository implements earthquakes.Repository for data provided by the USGS.
+type Repository struct {
+	cache *Cache
 }
 
-func ListEarthquakes(magnitude pb.Magnitude, past pb.Past,
-	limit int, details bool) (*pb.EarthquakeCollection, error) {
+// NewRepository creates a new repository accessing USGS data cached on the
+// default cache (shared with package functions).
+func NewRepository() *Repository {
+	return &Repository{cache: defaultCache}
+}
 
-	// get collection from the cache
-	col, err := cacheGetList(magnitude, past)
-	if err != nil {
-		return nil, err
-	}
+// NewRepositoryWithCache creates a new repository accessing USGS data cached
+// on the given cache.
+func NewRepositoryWithCache(c *Cache) *Repository {
+	return &Repository{cache: c}
+}
 
-	// return collection "as-is" if details was asked and no too many features
-	noLimit := limit <= 0
-	if details && (noLimit || len(col.Features) <= limit) {
-		return col, nil
-	}
+// defaultRepository is a repository used by package functions
+var defaultRepository = NewRepository()
 
-	// still here, we have to filter before returning feature collection
-	result := copyCollection(col, limit, details, nil, nil)
-	return result, nil
+// ensure that Repository implements the interface
+var _ earthquakes.Repository = (*Repository)(nil)
+
+// ListEarthquakes lists earthquakes on a order they are fetched from USGS.
+func (r *Repository) ListEarthquakes(ctx context.Context, q earthquakes.Query) (
+	*pb.EarthquakeCollection, string, error) {
+	return r.listEarthquakes(ctx, q, nil, nil, nil)
 }
 
-func ListEarthquakesFocusPosition(magnitude pb.Magnitude, past pb.Past,
-	limit int, details bool, pos *pb.GeoPointE7) (*pb.EarthquakeCollection, error) {
+// ListEarthquakesFocusPosition lists earthquakes nearest to the position
+// (within the max distance of a query if set).
+func (r *Repository) ListEarthquakesFocusPosition(ctx context.Context,
+	q earthquakes.Query, pos *pb.GeoPointE7) (
+	*pb.EarthquakeCollection, string, error) {
+	return r.listEarthquakes(ctx, q, pos, nil, nil)
+}
+
+// ListEarthquakesFocusBounds lists earthquakes inside bounds.
+func (r *Repository) ListEarthquakesFocusBounds(ctx context.Context,
+	q earthquakes.Query, bounds *pb.GeoBoundsE7) (
+	*pb.EarthquakeCollection, string, error) {
+	return r.listEarthquakes(ctx, q, nil, bounds, nil)
+}
 
-	// get collection from the cache
-	col, err := cacheGetList(magnitude, past)
+// ListEarthquakesFocusArea lists earthquakes inside an area of polygons.
+func (r *Repository) ListEarthquakesFocusArea(ctx context.Context,
+	q earthquakes.Query, area *pb.GeoMultiPolygonE7) (
+	*pb.EarthquakeCollection, string, error) {
+	a, err := earthquakes.NewArea(area)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	return r.listEarthquakes(ctx, q, nil, a.Bounds(), a)
+}
+
+// GetEarthquake returns an earthquake by id.
+func (r *Repository) GetEarthquake(ctx context.Context, id string) (
+	*pb.Earthquake, error) {
+	return r.cache.getById(ctx, id)
+}
+
+// GetEarthquakeProducts returns products from the detail feed by id.
+func (r *Repository) GetEarthquakeProducts(ctx context.Context, id string) (
+	*pb.EarthquakeProducts, error) {
+	return r.cache.getProducts(ctx, id)
+}
 
-	// filter resulting collection (and sort it by focusing on a position)
-	result := copyCollection(col, limit, details, pos, nil)
-	return result, nil
+// WatchEarthquakes streams events for earthquakes added, updated or deleted.
+func (r *Repository) WatchEarthquakes(q earthquakes.Query, pos *pb.GeoPointE7,
+	bounds *pb.GeoBoundsE7, done <-chan struct{}) (
+	<-chan []*pb.EarthquakeEvent, error) {
+	return r.cache.watchEarthquakes(q.Magnitude, q.Past, q.Details, pos, bounds,
+		done)
 }
 
-func ListEarthquakesFocusBounds(magnitude pb.Magnitude, past pb.Past,
+// -----------------------------------------------------------------------------
+
+func GetEarthquake(ctx context.Context, id string) (*pb.Earthquake, error) {
+	return defaultCache.getById(ctx, id)
+}
+
+func ListEarthquakes(ctx context.Context, magnitude pb.Magnitude, past pb.Past,
+	limit int, details bool) (*pb.EarthquakeCollection, error) {
+
+	col, _, err := defaultRepository.listEarthquakes(ctx, earthquakes.Query{
+		Magnitude: magnitude,
+		Past:      past,
+		Limit:     limit,
+		Details:   details,
+	}, nil, nil, nil)
+	return col, err
+}
+
+func ListEarthquakesFocusPosition(ctx context.Context, magnitude pb.Magnitude, past pb.Past,
+	limit int, details bool, pos *pb.GeoPointE7) (*pb.EarthquakeCollection, error) {
+
+	col, _, err := defaultRepository.listEarthquakes(ctx, earthquakes.Query{
+		Magnitude: magnitude,
+		Past:      past,
+		Limit:     limit,
+		Details:   details,
+	}, pos, nil, nil)
+	return col, err
+}
+
+func ListEarthquakesFocusBounds(ctx context.Context, magnitude pb.Magnitude, past pb.Past,
 	limit int, details bool, bounds *pb.GeoBoundsE7) (*pb.EarthquakeCollection, error) {
 
-	// get collection from the cache
-	col, err := cacheGetList(magnitude, past)
+	col, _, err := defaultRepository.listEarthquakes(ctx, earthquakes.Query{
+		Magnitude: magnitude,
+		Past:      past,
+		Limit:     limit,
+		Details:   details,
+	}, nil, bounds, nil)
+	return col, err
+}
+
+// listEarthquakes lists earthquakes matching a query, either all of them (if
+// both pos and bounds are nil), nearest to the position pos, or inside bounds
+// (and inside an area if not nil, with bounds of the area given as bounds)
+// (returns also a token for the next page if paging and more available)
+func (r *Repository) listEarthquakes(ctx context.Context, q earthquakes.Query,
+	pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7, area *earthquakes.Area) (
+	*pb.EarthquakeCollection, string, error) {
+
+	// get collection from the cache (or queried if not fitting in cache)
+	col, err := r.queryCollection(ctx, q, pos, bounds)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	// focus point (for sorting) at mid of bounding box
-	pos := &pb.GeoPointE7{
-		Latitude:  bounds.MinLatitude + (bounds.MaxLatitude-bounds.MinLatitude)/2,
-		Longitude: bounds.MinLongitude + (bounds.MaxLongitude-bounds.MinLongitude)/2,
-		Height:    bounds.MinHeight + (bounds.MaxHeight-bounds.MinHeight)/2,
+	if bounds != nil {
+		// focus point (for sorting) at mid of bounding box
+		pos = earthquakes.BoundsCenter(bounds)
+	} else if pos == nil {
+		// return collection "as-is" if details was asked, no too many
+		// features and no filters to be applied
+		noLimit := q.Limit <= 0
+		noFilter := !q.HasWindow() && !q.HasMagnitudeRange() &&
+			!q.HasDepthRange() && !q.HasSort() && !q.IsPaging()
+		if q.Details && noFilter && (noLimit || len(col.Features) <= q.Limit) {
+			return col, "", nil
+		}
 	}
 
 	// filter resulting collection (and sort it by focusing on a position for
-	// those earthquakes that locates inside bounds)
-	result := copyCollection(col, limit, details, pos, bounds)
-	return result, nil
+	// those earthquakes that locates inside bounds if any) using a spatial
+	// index if the collection is cached
+	var index *spatialIndex
+	if pos != nil {
+		index = r.cache.getSpatialIndex(q, col)
+	}
+	return copyCollection(col, index, q, pos, bounds, area)
 }
 
-func copyCollection(from *pb.EarthquakeCollection, limit int, details bool,
-	focus *pb.GeoPointE7, bounds *pb.GeoBoundsE7) *pb.EarthquakeCollection {
+// queryCollection returns a cached collection containing earthquakes for a
+// query, or if the query does not fit in cached feeds, a collection queried
+// from the FDSN event web service
+func (r *Repository) queryCollection(ctx context.Context, q earthquakes.Query,
+	pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7) (
+	*pb.EarthquakeCollection, error) {
+
+	now := time.Now()
+	magnitude, past, ok := resolveFeed(q, now)
+	if ok {
+		return r.cache.getList(ctx, magnitude, past)
+	}
+
+	// not cached, so need to query (and parse) earthquakes
+	start, end := q.Window(now)
+	data, err := r.cache.fetchQuery(ctx, q, start, end, pos, bounds)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		// no earthquakes found
+		return &pb.EarthquakeCollection{
+			Metadata: &pb.EarthquakeMetadata{GeneratedTime: now.Unix()},
+		}, nil
+	}
+	col, err := ToEarthquakeCollection(data, true)
+	if err != nil {
+		return nil, invalidData(r.cache.baseURL+queryPath, err)
+	}
+	return col, nil
+}
+
+// copyCollection copies earthquakes matching a query (and bounds if any) to
+// a new collection as a query pipeline:
+//  1. candidates from a spatial index (if not nil) for the collection near
+//     focus or inside bounds, or otherwise all earthquakes on the collection
+//  2. filters by the query (time window, magnitude and depth ranges),
+//     bounds, an area (if not nil, with bounds containing the area) and the
+//     max distance from focus (by the distance metric of the query)
+//  3. sorting by the sort key of the query, or if not set by distance to
+//     focus if any, or by time when paging (as on a feed otherwise)
+//  4. paging (the page after the token of a query, with a token for the
+//     next page returned) or limiting the number of earthquakes
+//  5. copying earthquakes (with a distance and a bearing from focus if
+//     any)
+func copyCollection(from *pb.EarthquakeCollection, index *spatialIndex,
+	q earthquakes.Query, focus *pb.GeoPointE7, bounds *pb.GeoBoundsE7,
+	area *earthquakes.Area) (*pb.EarthquakeCollection, string, error) {
 
 	to := &pb.EarthquakeCollection{}
 	if m := from.Metadata; m != nil {
@@ -86,57 +229,118 @@ func copyCollection(from *pb.EarthquakeCollection, limit int, details bool,
 		}
 	}
 
-	list := from.Features
-	if focus != nil {
-		// need to focus and sort earthquake features
-		type sorter struct {
-			eq   *pb.Earthquake
-			dist float64
+	// resolve order of earthquakes (when paging the order must be stable)
+	paging := q.IsPaging()
+	sortKey, ord := resolveOrder(q, focus)
+
+	// when paging skip earthquakes up to the last one on the previous page
+	limit := q.Limit
+	var after *pageKey
+	if paging {
+		limit = resolvePageSize(q)
+		if q.PageToken != "" {
+			key, err := decodePageToken(q.PageToken)
+			if err != nil || key.order != ord {
+				return nil, "", earthquakes.ErrInvalidPageToken
+			}
+			after = &key
+		}
+	}
+
+	// collect earthquakes matching filters (with sort keys if sorting)
+	filter := newQueryFilter(q, time.Now())
+	type sorter struct {
+		eq       *pb.Earthquake
+		key      pageKey
+		distance float64
+	}
+	var sorting []sorter
+	collect := func(eq *pb.Earthquake) bool {
+		if filter != nil && !filter.match(eq) {
+			return false
 		}
-		var sorting []sorter
-		for _, eq := range list {
-			pos := eq.Position
-			lat := pos.Latitude
-			lon := pos.Longitude
-			if bounds != nil {
-				if lat < bounds.MinLatitude || lat > bounds.MaxLatitude ||
-					lon < bounds.MinLongitude || lon > bounds.MaxLongitude {
-					continue // out of bounds, so skip
-				}
+		pos := eq.Position
+		if bounds != nil && !earthquakes.InBounds(pos, bounds) {
+			return false // out of bounds, so skip
+		}
+		if area != nil && !area.Contains(pos) {
+			return false // out of area, so skip
+		}
+		var distance float64
+		if focus != nil {
+			distance = q.Distance(focus, pos)
+			if q.MaxDistance > 0 && distance > q.MaxDistance {
+				return false // too far from focus, so skip
 			}
-			dist := geolib.DistanceE7(lat, lon, focus.Latitude, focus.Longitude)
-			sorting = append(sorting, sorter{eq: eq, dist: dist})
 		}
+		key := pageKey{order: ord, id: eq.Id}
+		if ord != orderFeed {
+			key.value = earthquakes.SortValue(sortKey, eq, distance)
+		}
+		if after != nil && !after.before(key) {
+			return false // on previous pages
+		}
+		sorting = append(sorting, sorter{eq: eq, key: key, distance: distance})
+		return true
+	}
+	switch {
+	case index != nil && bounds != nil:
+		index.within(bounds, func(eq *pb.Earthquake) { collect(eq) })
+	case index != nil && focus != nil && ord == orderDistance && limit > 0:
+		// nearest first (by surface distances as the index) with one more
+		// than limit to know if more earthquakes are available
+		index.nearest(focus, limit+1, q.MaxDistance, collect)
+	case index != nil && focus != nil && q.MaxDistance > 0 && !q.IsHypocentral():
+		// all within the max distance (that is a surface distance)
+		index.nearest(focus, 0, q.MaxDistance, collect)
+	default:
+		sorting = make([]sorter, 0, len(from.Features))
+		for _, eq := range from.Features {
+			collect(eq)
+		}
+	}
+	if ord != orderFeed {
 		sort.Slice(sorting, func(i, j int) bool {
-			return sorting[i].dist < sorting[j].dist
+			return sorting[i].key.before(sorting[j].key)
 		})
-		list = make([]*pb.Earthquake, len(sorting))
-		for i, s := range sorting {
-			list[i] = s.eq
-		}
 	}
 
 	// append features (up to number of limit) to resulting collection
-	for _, eq := range list {
+	for _, s := range sorting {
 		if limit > 0 && len(to.Features) >= limit {
 			break
 		}
-		if details {
+		eq := s.eq
+		switch {
+		case focus != nil:
+			// cached earthquakes are shared, so focus is set on a clone
+			clone := cloneEarthquakeWithoutDetails(eq)
+			if q.Details {
+				clone.Details = eq.Details
+			}
+			clone.DistanceMeters = s.distance
+			clone.BearingDegrees = geolib.BearingE7(
+				focus.Latitude, focus.Longitude,
+				eq.Position.Latitude, eq.Position.Longitude)
+			to.Features = append(to.Features, clone)
+		case q.Details:
 			to.Features = append(to.Features, eq)
-		} else {
+		default:
 			to.Features = append(to.Features,
 				cloneEarthquakeWithoutDetails(eq))
 		}
-		if to.Bounds == nil {
-			to.Bounds = createBounds(eq.Position)
-		} else {
-			addToBounds(to.Bounds, eq.Position)
-		}
 	}
+	to.Bounds = earthquakes.BoundsOf(to.Features)
 	if to.Metadata != nil {
 		to.Metadata.Count = int32(len(to.Features))
 	}
-	return to
+
+	// token for the next page if more earthquakes available
+	var next string
+	if paging && len(sorting) > len(to.Features) {
+		next = encodePageToken(sorting[len(to.Features)-1].key)
+	}
+	return to, next, nil
 }
 
 func cloneEarthquakeWithoutDetails(eq *pb.Earthquake) *pb.Earthquake {
@@ -152,23 +356,3 @@ func cloneEarthquakeWithoutDetails(eq *pb.Earthquake) *pb.Earthquake {
 		Significance:   eq.Significance,
 	}
 }
-
-func createBounds(pos *pb.GeoPointE7) *pb.GeoBoundsE7 {
-	return &pb.GeoBoundsE7{
-		MinLatitude:  pos.Latitude,
-		MinLongitude: pos.Longitude,
-		MinHeight:    pos.Height,
-		MaxLatitude:  pos.Latitude,
-		MaxLongitude: pos.Longitude,
-		MaxHeight:    pos.Height,
-	}
-}
-
-func addToBounds(bounds *pb.GeoBoundsE7, pos *pb.GeoPointE7) {
-	bounds.MinLatitude = mathlib.MinInt32(bounds.MinLatitude, pos.Latitude)
-	bounds.MinLongitude = mathlib.MinInt32(bounds.MinLongitude, pos.Longitude)
-	bounds.MinHeight = mathlib.MinInt32(bounds.MinHeight, pos.Height)
-	bounds.MaxLatitude = mathlib.MaxInt32(bounds.MaxLatitude, pos.Latitude)
-	bounds.MaxLongitude = mathlib.MaxInt32(bounds.MaxLongitude, pos.Longitude)
-	bounds.MaxHeight = mathlib.MaxInt32(bounds.MaxHeight, pos.Height)
-}
@@ -4,10 +4,15 @@
 package usgs
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/navibyte/quake/internal/geolib"
 	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
 )
 
 func TestRepository(t *testing.T) {
@@ -28,14 +33,15 @@ func TestRepository(t *testing.T) {
 func testRepository(t *testing.T, magnitude pb.Magnitude, past pb.Past,
 	limit int, bounds *pb.GeoBoundsE7) {
 
+	ctx := context.Background()
 	var col *pb.EarthquakeCollection
 	var err error
 
 	// list data from repository (true => ask for details)
 	if bounds == nil {
-		col, err = ListEarthquakes(magnitude, past, limit, true)
+		col, err = ListEarthquakes(ctx, magnitude, past, limit, true)
 	} else {
-		col, err = ListEarthquakesFocusBounds(magnitude, past, limit, true, bounds)
+		col, err = ListEarthquakesFocusBounds(ctx, magnitude, past, limit, true, bounds)
 	}
 	if err != nil {
 		t.Fatal(err)
@@ -54,9 +60,9 @@ func testRepository(t *testing.T, magnitude pb.Magnitude, past pb.Past,
 
 	// list data again from repository (false => without details)
 	if bounds == nil {
-		col, err = ListEarthquakes(magnitude, past, limit, false)
+		col, err = ListEarthquakes(ctx, magnitude, past, limit, false)
 	} else {
-		col, err = ListEarthquakesFocusBounds(magnitude, past, limit, false, bounds)
+		col, err = ListEarthquakesFocusBounds(ctx, magnitude, past, limit, false, bounds)
 	}
 	if err != nil {
 		t.Fatal(err)
@@ -71,3 +77,331 @@ func testRepository(t *testing.T, magnitude pb.Magnitude, past pb.Past,
 	}
 
 }
+
+func TestRepositoryAntimeridian(t *testing.T) {
+	// bounds from 170° to -170° crossing the antimeridian (Aleutians, Fiji
+	// and Kermadec Islands)
+	bounds := &pb.GeoBoundsE7{
+		MinLatitude:  -40_0000000,
+		MinLongitude: 170_0000000,
+		MaxLatitude:  60_0000000,
+		MaxLongitude: -170_0000000,
+	}
+	col, err := ListEarthquakesFocusBounds(context.Background(),
+		pb.Magnitude_MAGNITUDE_M45_PLUS, pb.Past_PAST_DAY, 0, false, bounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 9 {
+		t.Errorf("got %d features inside bounds, want 9", len(col.Features))
+	}
+	for _, eq := range col.Features {
+		if lon := eq.Position.Longitude; lon < 170_0000000 && lon > -170_0000000 {
+			t.Errorf("longitude %d out of bounds", lon)
+		}
+	}
+
+	// nearest to the center of bounds (at latitude 10° on the antimeridian)
+	center := earthquakes.BoundsCenter(bounds)
+	if center.Latitude != 10_0000000 || center.Longitude != 180_0000000 {
+		t.Errorf("center %v not on the antimeridian", center)
+	}
+	if len(col.Features) > 0 && col.Features[0].Position.Longitude != -172_2079000 {
+		t.Errorf("nearest to center %v", col.Features[0].Position)
+	}
+
+	// resulting bounds should be the minimal box crossing the antimeridian
+	b := col.Bounds
+	if b == nil || b.MinLongitude != 170_3780000 || b.MaxLongitude != -172_2079000 ||
+		b.MinLatitude != -33_4373000 || b.MaxLatitude != 53_2690000 {
+		t.Errorf("unexpected bounds %v", b)
+	}
+}
+
+func TestRepositoryMaxDistance(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryWithCache(newTestCache())
+	q := earthquakes.Query{
+		Magnitude:   pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:        pb.Past_PAST_DAY,
+		MaxDistance: 100_000,
+	}
+
+	// only two earthquakes (near Tonga) are within 100 km
+	pos := &pb.GeoPointE7{Latitude: -18_7000000, Longitude: -172_2000000}
+	col, _, err := r.ListEarthquakesFocusPosition(ctx, q, pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 2 {
+		t.Fatalf("got %d features within 100 km, want 2", len(col.Features))
+	}
+	prev := 0.0
+	for _, eq := range col.Features {
+		d := geolib.DistanceE7(pos.Latitude, pos.Longitude,
+			eq.Position.Latitude, eq.Position.Longitude)
+		if eq.DistanceMeters != d || d < prev || d > q.MaxDistance {
+			t.Errorf("invalid distance %f (computed %f)", eq.DistanceMeters, d)
+		}
+		prev = d
+	}
+
+	// the nearest one is south-southwest from the position
+	if b := col.Features[0].BearingDegrees; b < 180 || b > 225 {
+		t.Errorf("invalid bearing %f", b)
+	}
+
+	// focus is not set on cached earthquakes (nor listed without focus)
+	col, _, err = r.ListEarthquakes(ctx, earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+		Details:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, eq := range col.Features {
+		if eq.DistanceMeters != 0 || eq.BearingDegrees != 0 {
+			t.Errorf("focus set on %s listed without focus", eq.Id)
+		}
+	}
+}
+
+func TestRepositoryDepth(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryWithCache(newTestCache())
+	min, max := float32(30), float32(100)
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+		MinDepth:  &min,
+		MaxDepth:  &max,
+	}
+
+	// depth range of a query
+	col, _, err := r.ListEarthquakes(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 6 {
+		t.Errorf("got %d features between 30 and 100 km, want 6",
+			len(col.Features))
+	}
+
+	// height range of bounds (the Banda sea with deep earthquakes), and no
+	// height range if heights of bounds are 0
+	q = earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+	}
+	bounds := &pb.GeoBoundsE7{
+		MinLatitude: -10_0000000, MinLongitude: 120_0000000,
+		MinHeight:   -300_000_00,
+		MaxLatitude: 10_0000000, MaxLongitude: 140_0000000,
+		MaxHeight:   -100_000_00,
+	}
+	for _, want := range []int{2, 5} {
+		col, _, err = r.ListEarthquakesFocusBounds(ctx, q, bounds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(col.Features) != want {
+			t.Errorf("got %d features inside %v, want %d", len(col.Features),
+				bounds, want)
+		}
+		bounds.MinHeight, bounds.MaxHeight = 0, 0
+	}
+
+	// the nearest earthquake to a focus deep under us70006thw is another one
+	// by hypocentral distances
+	pos := &pb.GeoPointE7{
+		Latitude:  -5_7297000,
+		Longitude: 124_9909000,
+		Height:    -500_000_00,
+	}
+	q.Limit = 2
+	for _, test := range []struct {
+		metric  pb.DistanceMetric
+		nearest string
+	}{
+		{pb.DistanceMetric_DISTANCE_METRIC_UNSPECIFIED, "us70006thw"},
+		{pb.DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL, "us70006thf"},
+	} {
+		q.DistanceMetric = test.metric
+		col, _, err = r.ListEarthquakesFocusPosition(ctx, q, pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(col.Features) != 2 || col.Features[0].Id != test.nearest {
+			t.Fatalf("invalid nearest by %v: %v", test.metric, featureIds(col))
+		}
+		for _, eq := range col.Features {
+			if d := q.Distance(pos, eq.Position); eq.DistanceMeters != d {
+				t.Errorf("invalid distance %f (computed %f)", eq.DistanceMeters, d)
+			}
+		}
+	}
+	eq := col.Features[0]
+	r1, r2 := geolib.EarthRadius-500_000, geolib.EarthRadius-268_710
+	angle := geolib.DistanceE7(pos.Latitude, pos.Longitude,
+		eq.Position.Latitude, eq.Position.Longitude) / geolib.EarthRadius
+	want := math.Sqrt(r1*r1 + r2*r2 - 2*r1*r2*math.Cos(angle))
+	if math.Abs(eq.DistanceMeters-want) > 1 {
+		t.Errorf("hypocentral distance %f, want about %f", eq.DistanceMeters, want)
+	}
+
+	// page tokens are not valid for other distance metrics
+	q = earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+		PageSize:  5,
+	}
+	_, next, err := r.ListEarthquakesFocusPosition(ctx, q, pos)
+	if err != nil || next == "" {
+		t.Fatal("expected a token for the next page")
+	}
+	q.PageToken = next
+	q.DistanceMetric = pb.DistanceMetric_DISTANCE_METRIC_HYPOCENTRAL
+	if _, _, err = r.ListEarthquakesFocusPosition(ctx, q, pos); err != earthquakes.ErrInvalidPageToken {
+		t.Errorf("expected ErrInvalidPageToken, got %v", err)
+	}
+}
+
+func TestRepositorySort(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepositoryWithCache(newTestCache())
+
+	// top 3 strongest inside bounds crossing the antimeridian (with equal
+	// magnitudes ordered by id)
+	q := earthquakes.Query{
+		Magnitude:      pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:           pb.Past_PAST_DAY,
+		Limit:          3,
+		SortKey:        pb.SortKey_SORT_KEY_MAGNITUDE,
+		SortDescending: true,
+	}
+	col, _, err := r.ListEarthquakesFocusBounds(ctx, q, &pb.GeoBoundsE7{
+		MinLatitude: -40_0000000, MinLongitude: 170_0000000,
+		MaxLatitude: -10_0000000, MaxLongitude: -170_0000000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := fmt.Sprint(featureIds(col)); ids != "[us70006t8d us70006tcn us70006t8t]" {
+		t.Errorf("invalid strongest earthquakes %s", ids)
+	}
+
+	// all sort keys and directions (the same order also page by page)
+	pos := &pb.GeoPointE7{Latitude: 35_0000000, Longitude: 139_0000000}
+	for key := range pb.SortKey_name {
+		for _, descending := range []bool{false, true} {
+			q := earthquakes.Query{
+				Magnitude:      pb.Magnitude_MAGNITUDE_M45_PLUS,
+				Past:           pb.Past_PAST_DAY,
+				SortKey:        pb.SortKey(key),
+				SortDescending: descending && key != 0,
+			}
+			col, _, err := r.ListEarthquakesFocusPosition(ctx, q, pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 1; i < len(col.Features); i++ {
+				a, b := col.Features[i-1], col.Features[i]
+				sortKey := q.SortKey
+				if !q.HasSort() {
+					sortKey = pb.SortKey_SORT_KEY_DISTANCE
+				}
+				if earthquakes.SortBefore(
+					earthquakes.SortValue(sortKey, b, b.DistanceMeters), b.Id,
+					earthquakes.SortValue(sortKey, a, a.DistanceMeters), a.Id,
+					q.SortDescending) {
+					t.Errorf("%s before %s by %v (descending %v)", a.Id, b.Id,
+						sortKey, q.SortDescending)
+				}
+			}
+			var paged []string
+			q.PageSize = 4
+			for {
+				page, next, err := r.ListEarthquakesFocusPosition(ctx, q, pos)
+				if err != nil {
+					t.Fatal(err)
+				}
+				paged = append(paged, featureIds(page)...)
+				if next == "" {
+					break
+				}
+				q.PageToken = next
+			}
+			if fmt.Sprint(paged) != fmt.Sprint(featureIds(col)) {
+				t.Errorf("pages differ by %v (descending %v)", q.SortKey,
+					q.SortDescending)
+			}
+		}
+	}
+
+	// collections of feeds sorted are not shared
+	q = earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+		Past:      pb.Past_PAST_DAY,
+		Details:   true,
+		SortKey:   pb.SortKey_SORT_KEY_MAGNITUDE,
+	}
+	col, _, err = r.ListEarthquakes(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := featureIds(col); ids[0] != "us70006t5p" || ids[1] != "us70006tdj" {
+		t.Errorf("invalid weakest earthquakes %v", ids[:2])
+	}
+	q.SortKey = pb.SortKey_SORT_KEY_UNSPECIFIED
+	if col, _, err = r.ListEarthquakes(ctx, q); err != nil || col.Features[0].Id != "us70006thw" {
+		t.Error("feed order changed by sorting")
+	}
+}
+
+func TestRepositoryArea(t *testing.T) {
+	// Kermadec Islands and Tonga on a polygon crossing the antimeridian (with
+	// a hole around Tonga), and the Aleutians on another one
+	area, err := earthquakes.ParseGeoJSONArea([]byte(`{
+		"type": "MultiPolygon",
+		"coordinates": [
+			[
+				[[170, -40], [-170, -40], [-170, -10], [170, -10], [170, -40]],
+				[[-173, -19.5], [-171, -19.5], [-171, -18], [-173, -18], [-173, -19.5]]
+			],
+			[[[-179, 50], [-177, 50], [-177, 52], [-179, 52], [-179, 50]]]
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRepositoryWithCache(newTestCache())
+	col, _, err := r.ListEarthquakesFocusArea(context.Background(),
+		earthquakes.Query{
+			Magnitude: pb.Magnitude_MAGNITUDE_M45_PLUS,
+			Past:      pb.Past_PAST_DAY,
+		}, area)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Features) != 6 {
+		t.Errorf("got %d features inside the area, want 6", len(col.Features))
+	}
+	for _, eq := range col.Features {
+		pos := eq.Position
+		if pos.Latitude > -19_5000000 && pos.Latitude < -18_0000000 {
+			t.Errorf("%s inside a hole of the area", eq.Id)
+		}
+		if pos.Longitude < 170_0000000 && pos.Longitude > -170_0000000 {
+			t.Errorf("%s outside the area", eq.Id)
+		}
+	}
+
+	// not valid area
+	_, _, err = r.ListEarthquakesFocusArea(context.Background(),
+		earthquakes.Query{}, &pb.GeoMultiPolygonE7{})
+	var verr *earthquakes.ValidationError
+	if !errors.As(err, &verr) {
+		t.Errorf("expected a validation error for an empty area, got %v", err)
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"container/heap"
+	"math"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// minSpatialIndexSize is a minimum number of earthquakes on a collection for
+// building a spatial index (smaller collections are scanned linearly)
+const minSpatialIndexSize = 256
+
+// chordEpsilon widens chord distances compared (to tolerate rounding errors)
+const chordEpsilon = 1e-12
+
+// vec3 is a vector on 3D space (a unit vector for a position on a sphere)
+type vec3 [3]float64
+
+// box3 is an axis aligned bounding box on 3D space
+type box3 struct {
+	min, max vec3
+}
+
+// kdPoint is an earthquake on a k-d tree with a bounding box of a subtree
+// rooted at it
+type kdPoint struct {
+	v   vec3
+	eq  *pb.Earthquake
+	box box3
+}
+
+// spatialIndex is a k-d tree on unit vectors (positions of earthquakes on a
+// unit sphere) built once for each collection cached. The tree is implicit,
+// a subtree for points [lo, hi[ is rooted at (lo + hi) / 2.
+//
+// Nearest queries order earthquakes by chord distances that are monotonic to
+// great-circle distances (like distances by geolib.DistanceE7).
+type spatialIndex struct {
+	points []kdPoint
+}
+
+// getSpatialIndex returns a spatial index for a collection cached for a query,
+// or nil if the collection is not (or no longer) cached or too small
+func (c *Cache) getSpatialIndex(q earthquakes.Query,
+	col *pb.EarthquakeCollection) *spatialIndex {
+
+	if len(col.Features) < minSpatialIndexSize {
+		return nil
+	}
+	magnitude, past, ok := resolveFeed(q, time.Now())
+	if !ok {
+		return nil
+	}
+	entry := c.entries[resolveCacheKey(magnitude, past)]
+	if entry == nil {
+		return nil
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.col != col {
+		return nil
+	}
+	if entry.spatialIndexed != col {
+		entry.spatial = buildSpatialIndex(col)
+		entry.spatialIndexed = col
+	}
+	return entry.spatial
+}
+
+// buildSpatialIndex builds a spatial index for earthquakes on a collection
+func buildSpatialIndex(col *pb.EarthquakeCollection) *spatialIndex {
+	points := make([]kdPoint, len(col.Features))
+	for i, eq := range col.Features {
+		points[i] = kdPoint{v: toUnitVector(eq.Position), eq: eq}
+	}
+	buildTree(points)
+	return &spatialIndex{points: points}
+}
+
+// buildTree orders points as an implicit k-d tree, splitting by a median on
+// the widest axis of points
+func buildTree(points []kdPoint) {
+	if len(points) == 0 {
+		return
+	}
+	box := box3{min: points[0].v, max: points[0].v}
+	for _, p := range points[1:] {
+		for a := 0; a < 3; a++ {
+			box.min[a] = math.Min(box.min[a], p.v[a])
+			box.max[a] = math.Max(box.max[a], p.v[a])
+		}
+	}
+	axis := 0
+	for a := 1; a < 3; a++ {
+		if box.max[a]-box.min[a] > box.max[axis]-box.min[axis] {
+			axis = a
+		}
+	}
+	mid := len(points) / 2
+	selectMedian(points, mid, axis)
+	points[mid].box = box
+	buildTree(points[:mid])
+	buildTree(points[mid+1:])
+}
+
+// selectMedian reorders points so that a point at k is on its sorted position
+// by an axis, with points before it not greater and points after it not less
+// (a quickselect)
+func selectMedian(points []kdPoint, k, axis int) {
+	lo, hi := 0, len(points)-1
+	for lo < hi {
+		pivot := points[(lo+hi)/2].v[axis]
+		i, j := lo, hi
+		for i <= j {
+			for points[i].v[axis] < pivot {
+				i++
+			}
+			for points[j].v[axis] > pivot {
+				j--
+			}
+			if i <= j {
+				points[i], points[j] = points[j], points[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
+
+// nearest visits earthquakes nearest to a position first (and within a max
+// distance in meters if greater than 0) until n earthquakes are accepted by
+// visit (or all if n is 0). Earthquakes at the same distance as the last one
+// accepted are also visited, so that ties can be broken by the caller.
+func (s *spatialIndex) nearest(pos *pb.GeoPointE7, n int, maxDistance float64,
+	visit func(eq *pb.Earthquake) bool) {
+
+	q := toUnitVector(pos)
+	maxChord2 := math.Inf(1)
+	if angle := maxDistance / geolib.EarthRadius; maxDistance > 0 && angle < math.Pi {
+		chord := 2 * math.Sin(angle/2)
+		maxChord2 = chord * chord * (1 + chordEpsilon)
+	}
+
+	// best-first search over subtrees (by distance to bounding boxes) and
+	// points (by exact distance) on a priority queue
+	pq := &kdQueue{{lo: 0, hi: len(s.points), dist2: s.subtreeDist2(q, 0, len(s.points))}}
+	accepted := 0
+	var last float64
+	for pq.Len() > 0 {
+		it := heap.Pop(pq).(kdItem)
+		if it.dist2 > maxChord2 {
+			break
+		}
+		if n > 0 && accepted >= n && it.dist2 > last*(1+chordEpsilon)+chordEpsilon {
+			break
+		}
+		if it.point {
+			if visit(s.points[it.lo].eq) {
+				accepted++
+				last = it.dist2
+			}
+			continue
+		}
+		mid := (it.lo + it.hi) / 2
+		heap.Push(pq, kdItem{lo: mid, point: true, dist2: dist2(q, s.points[mid].v)})
+		if it.lo < mid {
+			heap.Push(pq, kdItem{lo: it.lo, hi: mid, dist2: s.subtreeDist2(q, it.lo, mid)})
+		}
+		if mid+1 < it.hi {
+			heap.Push(pq, kdItem{lo: mid + 1, hi: it.hi, dist2: s.subtreeDist2(q, mid+1, it.hi)})
+		}
+	}
+}
+
+// within visits earthquakes inside bounds (that may cross the antimeridian)
+func (s *spatialIndex) within(bounds *pb.GeoBoundsE7, visit func(eq *pb.Earthquake)) {
+	r := lonLatRegion{
+		minZ: math.Sin(toRadE7(bounds.MinLatitude)) - chordEpsilon,
+		maxZ: math.Sin(toRadE7(bounds.MaxLatitude)) + chordEpsilon,
+		west: toRadE7(bounds.MinLongitude),
+		span: float64(geolib.LonSpanE7(bounds.MinLongitude, bounds.MaxLongitude)) /
+			1e7 * math.Pi / 180,
+	}
+	s.withinSubtree(r, bounds, 0, len(s.points), visit)
+}
+
+func (s *spatialIndex) withinSubtree(r lonLatRegion, bounds *pb.GeoBoundsE7,
+	lo, hi int, visit func(eq *pb.Earthquake)) {
+
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	if !r.mayIntersect(s.points[mid].box) {
+		return // no point of a subtree inside bounds
+	}
+	if eq := s.points[mid].eq; earthquakes.InBounds(eq.Position, bounds) {
+		visit(eq)
+	}
+	s.withinSubtree(r, bounds, lo, mid, visit)
+	s.withinSubtree(r, bounds, mid+1, hi, visit)
+}
+
+// subtreeDist2 returns a squared distance from a vector to a bounding box of
+// a subtree for points [lo, hi[
+func (s *spatialIndex) subtreeDist2(v vec3, lo, hi int) float64 {
+	box := s.points[(lo+hi)/2].box
+	var d2 float64
+	for a := 0; a < 3; a++ {
+		if d := box.min[a] - v[a]; d > 0 {
+			d2 += d * d
+		} else if d := v[a] - box.max[a]; d > 0 {
+			d2 += d * d
+		}
+	}
+	return d2
+}
+
+// lonLatRegion is a region of bounds on a unit sphere, latitudes as a slab
+// along the z axis and longitudes as a wedge around it (from west to east by
+// span radians)
+type lonLatRegion struct {
+	minZ, maxZ float64
+	west, span float64
+}
+
+// mayIntersect returns false if a bounding box is certainly outside a region
+func (r lonLatRegion) mayIntersect(box box3) bool {
+	if box.max[2] < r.minZ || box.min[2] > r.maxZ {
+		return false
+	}
+	if r.span >= 2*math.Pi || (box.min[0] <= 0 && box.max[0] >= 0 &&
+		box.min[1] <= 0 && box.max[1] >= 0) {
+		return true // all longitudes or a box around the z axis
+	}
+
+	// a box not around the z axis has longitudes on an arc narrower than a
+	// half circle, bounded by longitudes of its corners
+	center := math.Atan2((box.min[1]+box.max[1])/2, (box.min[0]+box.max[0])/2)
+	lo, hi := 0.0, 0.0
+	for _, x := range [2]float64{box.min[0], box.max[0]} {
+		for _, y := range [2]float64{box.min[1], box.max[1]} {
+			d := math.Remainder(math.Atan2(y, x)-center, 2*math.Pi)
+			lo = math.Min(lo, d)
+			hi = math.Max(hi, d)
+		}
+	}
+	const eps = 1e-9
+	return arcContains(r.west, r.span+eps, center+lo-eps) ||
+		arcContains(center+lo-eps, hi-lo+2*eps, r.west)
+}
+
+// arcContains returns true if an angle is on an arc from start by width
+func arcContains(start, width, angle float64) bool {
+	d := math.Mod(angle-start, 2*math.Pi)
+	if d < 0 {
+		d += 2 * math.Pi
+	}
+	return d <= width
+}
+
+// toUnitVector returns a unit vector for a position on a sphere
+func toUnitVector(pos *pb.GeoPointE7) vec3 {
+	lat := toRadE7(pos.Latitude)
+	lon := toRadE7(pos.Longitude)
+	return vec3{
+		math.Cos(lat) * math.Cos(lon),
+		math.Cos(lat) * math.Sin(lon),
+		math.Sin(lat),
+	}
+}
+
+func toRadE7(e7 int32) float64 {
+	return float64(e7) / 1e7 * math.Pi / 180
+}
+
+func dist2(a, b vec3) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+// kdItem is a subtree for points [lo, hi[ or a point at lo (if point is true)
+// on a priority queue ordered by a squared distance
+type kdItem struct {
+	lo, hi int
+	point  bool
+	dist2  float64
+}
+
+// kdQueue implements heap.Interface for kdItems
+type kdQueue []kdItem
+
+func (q kdQueue) Len() int            { return len(q) }
+func (q kdQueue) Less(i, j int) bool  { return q[i].dist2 < q[j].dist2 }
+func (q kdQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *kdQueue) Push(x interface{}) { *q = append(*q, x.(kdItem)) }
+func (q *kdQueue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,263 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// randomCollection returns a collection of n earthquakes, clustered (like on
+// plate boundaries) with some of them on same positions
+func randomCollection(n int) *pb.EarthquakeCollection {
+	rnd := rand.New(rand.NewSource(1))
+	col := &pb.EarthquakeCollection{}
+	clusters := make([]*pb.GeoPointE7, 20)
+	for i := range clusters {
+		clusters[i] = &pb.GeoPointE7{
+			Latitude:  rnd.Int31n(170_0000000) - 85_0000000,
+			Longitude: int32(rnd.Int63n(360_0000000) - 180_0000000),
+		}
+	}
+	for i := 0; i < n; i++ {
+		var pos *pb.GeoPointE7
+		switch {
+		case i%10 == 0:
+			// anywhere
+			pos = &pb.GeoPointE7{
+				Latitude:  rnd.Int31n(180_0000000) - 90_0000000,
+				Longitude: int32(rnd.Int63n(360_0000000) - 180_0000000),
+			}
+		case i%10 == 1 && i > 1:
+			// same position as the previous one
+			pos = col.Features[i-1].Position
+		default:
+			c := clusters[rnd.Intn(len(clusters))]
+			lon := int64(c.Longitude) + int64(rnd.Int31n(10_0000000)) - 5_0000000
+			if lon > 180_0000000 {
+				lon -= 360_0000000
+			} else if lon < -180_0000000 {
+				lon += 360_0000000
+			}
+			pos = &pb.GeoPointE7{
+				Latitude:  c.Latitude + rnd.Int31n(10_0000000) - 5_0000000,
+				Longitude: int32(lon),
+			}
+		}
+		col.Features = append(col.Features, &pb.Earthquake{
+			Id:        fmt.Sprintf("eq%05d", i),
+			Position:  pos,
+			Magnitude: 1 + 6*rnd.Float32(),
+			Time:      1578000000 - int64(i)*60,
+		})
+	}
+	return col
+}
+
+func featureIds(col *pb.EarthquakeCollection) []string {
+	ids := make([]string, len(col.Features))
+	for i, eq := range col.Features {
+		ids[i] = eq.Id
+	}
+	return ids
+}
+
+func TestSpatialIndexNearest(t *testing.T) {
+	col := randomCollection(5000)
+	index := buildSpatialIndex(col)
+	min := float32(4.0)
+	foci := []*pb.GeoPointE7{
+		col.Features[11].Position, // a position with two earthquakes
+		{Latitude: 35_0000000, Longitude: 139_0000000},
+		{Latitude: -18_0000000, Longitude: 180_0000000},
+		{Latitude: 90_0000000, Longitude: 0},
+		{Latitude: -89_0000000, Longitude: -179_9000000},
+	}
+	for _, focus := range foci {
+		for _, q := range []earthquakes.Query{
+			{Limit: 1},
+			{Limit: 10},
+			{Limit: 100, MinMagnitude: &min},
+			{MaxDistance: 1000_000},
+			{Limit: 50, MaxDistance: 300_000},
+			{Limit: 20, SortKey: pb.SortKey_SORT_KEY_DISTANCE},
+			{Limit: 10, MaxDistance: 2000_000, SortKey: pb.SortKey_SORT_KEY_MAGNITUDE,
+				SortDescending: true},
+		} {
+			want, _, err := copyCollection(col, nil, q, focus, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _, err := copyCollection(col, index, q, focus, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(featureIds(got)) != fmt.Sprint(featureIds(want)) {
+				t.Errorf("nearest to %v by %+v: got %v, want %v", focus, q,
+					featureIds(got), featureIds(want))
+			}
+		}
+	}
+}
+
+func TestSpatialIndexWithin(t *testing.T) {
+	col := randomCollection(5000)
+	index := buildSpatialIndex(col)
+	for _, bounds := range []*pb.GeoBoundsE7{
+		{MinLatitude: 10_0000000, MinLongitude: -70_0000000,
+			MaxLatitude: 40_0000000, MaxLongitude: -10_0000000},
+		{MinLatitude: -40_0000000, MinLongitude: 170_0000000,
+			MaxLatitude: 60_0000000, MaxLongitude: -170_0000000},
+		{MinLatitude: 60_0000000, MinLongitude: -180_0000000,
+			MaxLatitude: 90_0000000, MaxLongitude: 180_0000000},
+		{MinLatitude: -90_0000000, MinLongitude: 100_0000000,
+			MaxLatitude: 90_0000000, MaxLongitude: 90_0000000},
+		{MinLatitude: 0, MinLongitude: 0, MaxLatitude: 0, MaxLongitude: 0},
+	} {
+		var want, got []string
+		for _, eq := range col.Features {
+			if earthquakes.InBounds(eq.Position, bounds) {
+				want = append(want, eq.Id)
+			}
+		}
+		index.within(bounds, func(eq *pb.Earthquake) {
+			got = append(got, eq.Id)
+		})
+		sort.Strings(got)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("within %v: got %d, want %d earthquakes", bounds,
+				len(got), len(want))
+		}
+	}
+}
+
+func TestSpatialIndexPaging(t *testing.T) {
+	col := randomCollection(2000)
+	index := buildSpatialIndex(col)
+	focus := &pb.GeoPointE7{Latitude: 35_0000000, Longitude: 139_0000000}
+	q := earthquakes.Query{PageSize: 100, MaxDistance: 5000_000}
+	for {
+		want, wantNext, err := copyCollection(col, nil, q, focus, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, next, err := copyCollection(col, index, q, focus, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(featureIds(got)) != fmt.Sprint(featureIds(want)) ||
+			next != wantNext {
+			t.Fatal("pages differ with a spatial index")
+		}
+		if next == "" {
+			break
+		}
+		q.PageToken = next
+	}
+}
+
+func TestCacheSpatialIndex(t *testing.T) {
+	// set a valid collection on the cache for the "30days" list
+	col := randomCollection(1000)
+	c := newTestCache()
+	entry := c.entries[resolveCacheKey(pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_30DAYS)]
+	entry.mu.Lock()
+	entry.col = col
+	entry.expires = time.Now().Add(time.Minute)
+	entry.mu.Unlock()
+
+	// built once for a collection cached
+	q := earthquakes.Query{
+		Magnitude: pb.Magnitude_MAGNITUDE_ALL,
+		Past:      pb.Past_PAST_30DAYS,
+	}
+	index := c.getSpatialIndex(q, col)
+	if index == nil || len(index.points) != len(col.Features) {
+		t.Fatal("no spatial index for a collection cached")
+	}
+	if c.getSpatialIndex(q, col) != index {
+		t.Error("spatial index built again")
+	}
+
+	// not for collections not cached (or too small)
+	if c.getSpatialIndex(q, randomCollection(1000)) != nil {
+		t.Error("spatial index for a collection not cached")
+	}
+	q.Magnitude = pb.Magnitude_MAGNITUDE_M45_PLUS
+	if c.getSpatialIndex(q, col) != nil {
+		t.Error("spatial index for a collection of other feed")
+	}
+	small := &pb.EarthquakeCollection{Features: col.Features[:10]}
+	entry.mu.Lock()
+	entry.col = small
+	entry.mu.Unlock()
+	q.Magnitude = pb.Magnitude_MAGNITUDE_ALL
+	if c.getSpatialIndex(q, small) != nil {
+		t.Error("spatial index for a small collection")
+	}
+}
+
+func BenchmarkBuildSpatialIndex(b *testing.B) {
+	col := randomCollection(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buildSpatialIndex(col)
+	}
+}
+
+func benchmarkFocus(b *testing.B, index *spatialIndex, col *pb.EarthquakeCollection,
+	q earthquakes.Query, focus *pb.GeoPointE7, bounds *pb.GeoBoundsE7) {
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := copyCollection(col, index, q, focus, bounds, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+var (
+	benchFocus  = &pb.GeoPointE7{Latitude: 35_0000000, Longitude: 139_0000000}
+	benchBounds = &pb.GeoBoundsE7{MinLatitude: 30_0000000, MinLongitude: 130_0000000,
+		MaxLatitude: 40_0000000, MaxLongitude: 150_0000000}
+)
+
+func BenchmarkNearestLinear(b *testing.B) {
+	benchmarkFocus(b, nil, randomCollection(10000),
+		earthquakes.Query{Limit: 100}, benchFocus, nil)
+}
+
+func BenchmarkNearestIndexed(b *testing.B) {
+	col := randomCollection(10000)
+	benchmarkFocus(b, buildSpatialIndex(col), col,
+		earthquakes.Query{Limit: 100}, benchFocus, nil)
+}
+
+func BenchmarkMaxDistanceLinear(b *testing.B) {
+	benchmarkFocus(b, nil, randomCollection(10000),
+		earthquakes.Query{MaxDistance: 500_000}, benchFocus, nil)
+}
+
+func BenchmarkMaxDistanceIndexed(b *testing.B) {
+	col := randomCollection(10000)
+	benchmarkFocus(b, buildSpatialIndex(col), col,
+		earthquakes.Query{MaxDistance: 500_000}, benchFocus, nil)
+}
+
+func BenchmarkBoundsLinear(b *testing.B) {
+	benchmarkFocus(b, nil, randomCollection(10000),
+		earthquakes.Query{}, earthquakes.BoundsCenter(benchBounds), benchBounds)
+}
+
+func BenchmarkBoundsIndexed(b *testing.B) {
+	col := randomCollection(10000)
+	benchmarkFocus(b, buildSpatialIndex(col), col,
+		earthquakes.Query{}, earthquakes.BoundsCenter(benchBounds), benchBounds)
+}
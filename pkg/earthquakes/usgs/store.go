@@ -0,0 +1,151 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+// storeVersion is written first on stored files (to detect other formats)
+const storeVersion = 2
+
+// storeFileExt is a file extension for stored files (named by cache keys)
+const storeFileExt = ".cache"
+
+// ErrInvalidStoreFile is returned when a stored file cannot be decoded
+var ErrInvalidStoreFile = errors.New("invalid cache store file")
+
+// SetStoreDir enables a disk store on dir for fetched collections and loads
+// collections stored earlier to the default cache. Stored collections are
+// used until expired, and after that as a stale fallback when fetches fail.
+// This should be called at startup before serving any requests.
+func SetStoreDir(dir string) error {
+	return defaultCache.SetStoreDir(dir)
+}
+
+// SetStoreDir enables a disk store on dir for collections fetched to the cache
+// and loads collections stored earlier (see the SetStoreDir function).
+func (c *Cache) SetStoreDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	c.storeDir = dir
+
+	// load stored collections for all cache entries
+	for _, magn := range pb.Magnitude_value {
+		for _, past := range pb.Past_value {
+			magnitude, past := pb.Magnitude(magn), pb.Past(past)
+			key := resolveCacheKey(magnitude, past)
+			entry := c.entries[key]
+			entry.mu.Lock()
+			err := storeLoad(dir, key, entry)
+			if err == nil {
+				c.setStat(magnitude, past, entry.stat)
+			}
+			entry.mu.Unlock()
+			if err != nil && !os.IsNotExist(err) {
+				log.Printf("error %v loading %s from the store", err, key)
+			}
+		}
+	}
+	return nil
+}
+
+// storeSave saves a collection (with expiry, stats and validators) of an entry
+// to dir (must be called when holding a lock)
+func storeSave(dir string, key string, entry *entry) error {
+	buf := proto.NewBuffer(nil)
+	buf.EncodeVarint(storeVersion)
+	buf.EncodeZigzag64(uint64(entry.expires.Unix()))
+	buf.EncodeVarint(uint64(entry.fetchCount))
+	buf.EncodeVarint(uint64(entry.hitCount))
+	buf.EncodeStringBytes(entry.resource.etag)
+	buf.EncodeStringBytes(entry.resource.lastModified)
+	buf.EncodeVarint(uint64(entry.resource.size))
+	if err := buf.EncodeMessage(entry.col); err != nil {
+		return err
+	}
+
+	// write to a temporary file first and then rename it, so that readers
+	// never see partially written files
+	tmp, err := ioutil.TempFile(dir, key+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), storePath(dir, key))
+}
+
+// storeLoad loads a collection stored on dir to an entry (must be called when
+// holding a lock)
+func storeLoad(dir string, key string, entry *entry) error {
+	data, err := ioutil.ReadFile(storePath(dir, key))
+	if err != nil {
+		return err
+	}
+	buf := proto.NewBuffer(data)
+	version, err := buf.DecodeVarint()
+	if err != nil || version != storeVersion {
+		return ErrInvalidStoreFile
+	}
+	expires, err := buf.DecodeZigzag64()
+	if err != nil {
+		return ErrInvalidStoreFile
+	}
+	fetchCount, err := buf.DecodeVarint()
+	if err != nil {
+		return ErrInvalidStoreFile
+	}
+	hitCount, err := buf.DecodeVarint()
+	if err != nil {
+		return ErrInvalidStoreFile
+	}
+	etag, err := buf.DecodeStringBytes()
+	if err != nil {
+		return ErrInvalidStoreFile
+	}
+	lastModified, err := buf.DecodeStringBytes()
+	if err != nil {
+		return ErrInvalidStoreFile
+	}
+	size, err := buf.DecodeVarint()
+	if err != nil {
+		return ErrInvalidStoreFile
+	}
+	col := &pb.EarthquakeCollection{}
+	if err := buf.DecodeMessage(col); err != nil {
+		return ErrInvalidStoreFile
+	}
+
+	entry.col = col
+	entry.expires = time.Unix(int64(expires), 0)
+	entry.fetchCount = int(fetchCount)
+	entry.hitCount = int(hitCount)
+	entry.resource = resource{
+		etag:         etag,
+		lastModified: lastModified,
+		size:         int(size),
+	}
+	return nil
+}
+
+func storePath(dir string, key string) string {
+	return filepath.Join(dir, key+storeFileExt)
+}
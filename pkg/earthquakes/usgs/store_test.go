@@ -0,0 +1,64 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "quake-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// save an entry and load it again to another entry
+	expires := time.Now().Add(time.Minute).Truncate(time.Second)
+	saved := &entry{col: col, expires: expires}
+	saved.fetchCount = 3
+	saved.hitCount = 7
+	saved.resource = resource{etag: `"abc"`, lastModified: "Thu, 02 Jan 2020 12:27:30 GMT", size: 100}
+	if err := storeSave(dir, "test", saved); err != nil {
+		t.Fatal(err)
+	}
+	loaded := &entry{}
+	if err := storeLoad(dir, "test", loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(loaded.col, col) {
+		t.Error("loaded collection differs from saved one")
+	}
+	if !loaded.expires.Equal(expires) {
+		t.Error("invalid expires")
+	}
+	if loaded.fetchCount != 3 || loaded.hitCount != 7 {
+		t.Error("invalid stat")
+	}
+	if loaded.resource != saved.resource {
+		t.Error("invalid validators")
+	}
+
+	// loading corrupted files should fail
+	if err := ioutil.WriteFile(storePath(dir, "test"), []byte{9, 9}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := storeLoad(dir, "test", &entry{}); err != ErrInvalidStoreFile {
+		t.Error("expected ErrInvalidStoreFile")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+// adaptiveGrowth is a factor lengthening a time to live on quiet periods
+const adaptiveGrowth = 2
+
+// RefreshInfo describes a refresh of a feed that a time to live is resolved
+// for. When a time to live is needed without a refresh (like before a feed is
+// fetched), only a magnitude and a past are set.
+type RefreshInfo struct {
+	Magnitude pb.Magnitude
+	Past      pb.Past
+
+	// Changed is a number of earthquakes added, updated or deleted on a
+	// refresh (0 also when data was not modified or on the first fetch)
+	Changed int
+
+	// NewSignificant is a number of significant earthquakes added on a refresh
+	NewSignificant int
+
+	// MaxAge is a max-age of the Cache-Control header of an upstream response
+	// (0 if not present)
+	MaxAge time.Duration
+
+	// Previous is a time to live resolved on a previous refresh (0 if none)
+	Previous time.Duration
+}
+
+// TTLPolicy resolves a time to live for a collection of a feed refreshed.
+type TTLPolicy func(info RefreshInfo) time.Duration
+
+// DefaultTTL is the default time to live policy (3, 5, 10 or 15 minutes by a
+// past of a feed).
+func DefaultTTL(info RefreshInfo) time.Duration {
+	return resolveMaxAge(info.Magnitude, info.Past)
+}
+
+// FeedTTL returns a policy with time to live overrides for feeds named like
+// the USGS summary feeds ("4.5_day", "all_hour" or "significant_month"). Other
+// feeds fall back to a policy given. Returns ErrUnknownDataRequest if a feed
+// name is not known.
+func FeedTTL(overrides map[string]time.Duration, fallback TTLPolicy) (
+	TTLPolicy, error) {
+
+	// map overrides by cache key for lookups
+	byKey := make(map[string]time.Duration, len(overrides))
+	for name, ttl := range overrides {
+		magnitude, past, ok := resolveFeedName(name)
+		if !ok {
+			return nil, ErrUnknownDataRequest
+		}
+		byKey[resolveCacheKey(magnitude, past)] = ttl
+	}
+	return func(info RefreshInfo) time.Duration {
+		if ttl, ok := byKey[resolveCacheKey(info.Magnitude, info.Past)]; ok {
+			return ttl
+		}
+		return fallback(info)
+	}, nil
+}
+
+// AdaptiveTTL returns a policy adapting a time to live resolved by a base
+// policy (or by max-age of the Cache-Control header of an upstream response
+// when present). When a refresh brings new significant earthquakes, the time
+// to live is shortened to min. When a refresh has no changes (a quiet period),
+// the time to live is lengthened from the previous one. The time to live is
+// always between min and max.
+func AdaptiveTTL(base TTLPolicy, min, max time.Duration) TTLPolicy {
+	return func(info RefreshInfo) time.Duration {
+		if info.NewSignificant > 0 {
+			return min
+		}
+		ttl := base(info)
+		if info.MaxAge > 0 {
+			ttl = info.MaxAge
+		}
+		if info.Changed == 0 && info.Previous > 0 {
+			if lengthened := info.Previous * adaptiveGrowth; lengthened > ttl {
+				ttl = lengthened
+			}
+		}
+		switch {
+		case ttl < min:
+			return min
+		case ttl > max:
+			return max
+		default:
+			return ttl
+		}
+	}
+}
+
+// resolveRefreshInfo describes a refresh of an entry with events for changes
+// (must be called when holding a lock)
+func (e *entry) resolveRefreshInfo(events []*pb.EarthquakeEvent,
+	maxAge time.Duration) RefreshInfo {
+
+	info := RefreshInfo{
+		Magnitude: e.magnitude,
+		Past:      e.past,
+		MaxAge:    maxAge,
+		Previous:  e.ttl,
+	}
+	if e.col == nil {
+		// on the first fetch nothing is changed
+		return info
+	}
+	info.Changed = len(events)
+	for _, ev := range events {
+		if ev.Type == pb.EventType_EVENT_TYPE_ADDED &&
+			ev.Feature.Significance >= significantMin {
+			info.NewSignificant++
+		}
+	}
+	return info
+}
+
+// maxAge returns a current time to live for an entry (resolved on the latest
+// refresh or by the policy if not yet refreshed)
+func (c *Cache) maxAge(magnitude pb.Magnitude, past pb.Past) time.Duration {
+	entry := c.entries[resolveCacheKey(magnitude, past)]
+	if entry != nil {
+		entry.mu.Lock()
+		defer entry.mu.Unlock()
+		return entry.maxAge()
+	}
+	return c.ttl(RefreshInfo{Magnitude: magnitude, Past: past})
+}
+
+// maxAge returns a current time to live for an entry (must be called when
+// holding a lock)
+func (e *entry) maxAge() time.Duration {
+	if e.ttl > 0 {
+		return e.ttl
+	}
+	return e.cache.ttl(RefreshInfo{Magnitude: e.magnitude, Past: e.past})
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestFeedTTL(t *testing.T) {
+	policy, err := FeedTTL(map[string]time.Duration{
+		"4.5_day":   time.Minute,
+		"all_month": time.Hour,
+	}, DefaultTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		magnitude pb.Magnitude
+		past      pb.Past
+		ttl       time.Duration
+	}{
+		{pb.Magnitude_MAGNITUDE_M45_PLUS, pb.Past_PAST_DAY, time.Minute},
+		{pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_30DAYS, time.Hour},
+		{pb.Magnitude_MAGNITUDE_ALL, pb.Past_PAST_DAY, 5 * time.Minute},
+	}
+	for _, test := range tests {
+		info := RefreshInfo{Magnitude: test.magnitude, Past: test.past}
+		if ttl := policy(info); ttl != test.ttl {
+			t.Errorf("invalid ttl %v for %v@%v", ttl, test.magnitude, test.past)
+		}
+	}
+
+	if _, err := FeedTTL(map[string]time.Duration{"4.5_year": time.Minute},
+		DefaultTTL); err != ErrUnknownDataRequest {
+		t.Error("expected ErrUnknownDataRequest")
+	}
+}
+
+func TestAdaptiveTTL(t *testing.T) {
+	policy := AdaptiveTTL(DefaultTTL, time.Minute, 30*time.Minute)
+	day := RefreshInfo{Magnitude: pb.Magnitude_MAGNITUDE_ALL, Past: pb.Past_PAST_DAY}
+	tests := []struct {
+		name   string
+		update func(info *RefreshInfo)
+		ttl    time.Duration
+	}{
+		{"first fetch", func(info *RefreshInfo) {}, 5 * time.Minute},
+		{"new significant", func(info *RefreshInfo) {
+			info.Previous = 5 * time.Minute
+			info.Changed = 2
+			info.NewSignificant = 1
+		}, time.Minute},
+		{"changes", func(info *RefreshInfo) {
+			info.Previous = time.Minute
+			info.Changed = 2
+		}, 5 * time.Minute},
+		{"quiet", func(info *RefreshInfo) {
+			info.Previous = 5 * time.Minute
+		}, 10 * time.Minute},
+		{"quiet long", func(info *RefreshInfo) {
+			info.Previous = 20 * time.Minute
+		}, 30 * time.Minute},
+		{"cache control", func(info *RefreshInfo) {
+			info.Previous = 5 * time.Minute
+			info.Changed = 1
+			info.MaxAge = 2 * time.Minute
+		}, 2 * time.Minute},
+		{"cache control short", func(info *RefreshInfo) {
+			info.Changed = 1
+			info.MaxAge = 10 * time.Second
+		}, time.Minute},
+	}
+	for _, test := range tests {
+		info := day
+		test.update(&info)
+		if ttl := policy(info); ttl != test.ttl {
+			t.Errorf("%s: invalid ttl %v", test.name, ttl)
+		}
+	}
+}
+
+func TestResolveRefreshInfo(t *testing.T) {
+	prev := &pb.EarthquakeCollection{Features: []*pb.Earthquake{
+		{Id: "a", Significance: 700},
+	}}
+	next := &pb.EarthquakeCollection{Features: []*pb.Earthquake{
+		{Id: "a", Significance: 700},
+		{Id: "b", Significance: 650},
+		{Id: "c", Significance: 100},
+	}}
+	e := &entry{col: prev, ttl: time.Minute}
+	info := e.resolveRefreshInfo(diffCollections(prev, next), time.Second)
+	if info.Changed != 2 || info.NewSignificant != 1 ||
+		info.Previous != time.Minute || info.MaxAge != time.Second {
+		t.Errorf("invalid refresh info %+v", info)
+	}
+
+	// nothing changed on the first fetch
+	e = &entry{}
+	if info := e.resolveRefreshInfo(diffCollections(nil, next), 0); info.Changed != 0 ||
+		info.NewSignificant != 0 {
+		t.Errorf("invalid refresh info %+v", info)
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	fake.SetCacheControl("public, max-age=120")
+	defer fake.Reset()
+
+	// an adaptive policy follows max-age of the upstream response
+	c := newTestCache(WithTTLPolicy(AdaptiveTTL(DefaultTTL, time.Second, time.Hour)))
+	magnitude, past := pb.Magnitude_MAGNITUDE_M45_PLUS, pb.Past_PAST_DAY
+	if _, err := c.getList(context.Background(), magnitude, past); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := c.maxAge(magnitude, past); ttl != 2*time.Minute {
+		t.Errorf("invalid ttl %v", ttl)
+	}
+
+	// the default policy does not
+	c = newTestCache()
+	if _, err := c.getList(context.Background(), magnitude, past); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := c.maxAge(magnitude, past); ttl != 5*time.Minute {
+		t.Errorf("invalid ttl %v", ttl)
+	}
+}
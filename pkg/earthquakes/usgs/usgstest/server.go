@@ -0,0 +1,214 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+// Package usgstest provides a local stand-in for the USGS web services
+// (summary feeds and the FDSN event web service) for tests. Feeds are served
+// from data (like fixture files) set on a server, with controllable latency,
+// errors, 5xx responses and malformed JSON.
+package usgstest
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	feedPath    = "/earthquakes/feed/v1.0/summary/"
+	feedPostfix = ".geojson"
+	queryPath   = "/fdsnws/event/1/query"
+)
+
+// QueryFeed is a name of a feed served as a response for all queries to the
+// FDSN event web service.
+const QueryFeed = "query"
+
+// Server is a fake USGS web service. Use URL of the server as a base URL for
+// a cache of the usgs package (see usgs.WithBaseURL).
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	feeds        map[string][]byte
+	cacheControl string
+	latency      time.Duration
+	failures     []failure
+	requests     map[string]int
+}
+
+// failure is a failure injected for a next request
+type failure struct {
+	status    int  // status code of a response (if not dropped or malformed)
+	drop      bool // close a connection without a response
+	malformed bool // respond with malformed JSON
+}
+
+// NewServer starts and returns a new fake USGS web service. The caller should
+// call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		feeds:    make(map[string][]byte),
+		requests: make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// SetFeed sets data for a feed named like the USGS summary feeds ("4.5_day",
+// "all_hour" or "significant_month") or QueryFeed.
+func (s *Server) SetFeed(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feeds[name] = data
+}
+
+// SetFeedFile sets data for a feed from a file (like a fixture on testdata).
+func (s *Server) SetFeedFile(name string, filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	s.SetFeed(name, data)
+	return nil
+}
+
+// SetCacheControl sets a Cache-Control header (like "max-age=60") for
+// responses (or none if empty).
+func (s *Server) SetCacheControl(value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cacheControl = value
+}
+
+// SetLatency sets a delay before responding to each request.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext makes next n requests fail with a status code (like 503).
+func (s *Server) FailNext(n int, status int) {
+	s.addFailures(n, failure{status: status})
+}
+
+// DropNext makes next n requests fail by closing connections without any
+// response (clients get errors).
+func (s *Server) DropNext(n int) {
+	s.addFailures(n, failure{drop: true})
+}
+
+// MalformNext makes next n requests respond with malformed JSON.
+func (s *Server) MalformNext(n int) {
+	s.addFailures(n, failure{status: http.StatusOK, malformed: true})
+}
+
+// Reset clears failures injected, latency, Cache-Control and request counts
+// (but not feeds).
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+	s.cacheControl = ""
+	s.latency = 0
+	s.requests = make(map[string]int)
+}
+
+// Requests returns a number of requests to a feed.
+func (s *Server) Requests(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[name]
+}
+
+func (s *Server) addFailures(n int, f failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, f)
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	// resolve a feed requested
+	var name string
+	switch {
+	case strings.HasPrefix(r.URL.Path, feedPath) &&
+		strings.HasSuffix(r.URL.Path, feedPostfix):
+		name = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, feedPath),
+			feedPostfix)
+	case r.URL.Path == queryPath:
+		name = QueryFeed
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	// count a request and take a failure injected (if any)
+	s.mu.Lock()
+	s.requests[name]++
+	latency := s.latency
+	var f *failure
+	if len(s.failures) > 0 {
+		f = &s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	data, ok := s.feeds[name]
+	cacheControl := s.cacheControl
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if f != nil {
+		switch {
+		case f.drop:
+			if hj, ok := w.(http.Hijacker); ok {
+				if conn, _, err := hj.Hijack(); err == nil {
+					conn.Close()
+					return
+				}
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		case f.malformed:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"type":"FeatureCollection","features":[{`))
+		default:
+			w.WriteHeader(f.status)
+		}
+		return
+	}
+
+	if !ok {
+		if name == QueryFeed {
+			// like the FDSN event web service when no earthquakes found
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.NotFound(w, r)
+		return
+	}
+
+	// support conditional requests like the USGS does
+	etag := fmt.Sprintf(`"%x"`, sha1.Sum(data))
+	w.Header().Set("ETag", etag)
+	if cacheControl != "" {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	pb "github.com/navibyte/quake/api/v1"
+	"github.com/navibyte/quake/internal/geolib"
+	"github.com/navibyte/quake/pkg/earthquakes"
+)
+
+// watcherBufferSize is a number of event batches buffered for a watcher
+const watcherBufferSize = 16
+
+// watcher receives batches of events when a cache entry is refreshed
+type watcher struct {
+	events chan []*pb.EarthquakeEvent
+	closed bool
+}
+
+func newWatcher() *watcher {
+	return &watcher{events: make(chan []*pb.EarthquakeEvent, watcherBufferSize)}
+}
+
+// close closes the events channel (must be called when holding an entry lock)
+func (w *watcher) close() {
+	if !w.closed {
+		w.closed = true
+		close(w.events)
+	}
+}
+
+// WatchEarthquakes streams batches of events for earthquakes added, updated or
+// deleted when cached data is refreshed. The first batch contains earthquakes
+// currently available as added events. If bounds is set, only earthquakes
+// inside bounds are included, and if pos is set, events on a batch are sorted
+// by distance to the position. The returned channel is closed after done is
+// closed or if a receiver is lagging behind too much.
+func WatchEarthquakes(magnitude pb.Magnitude, past pb.Past, details bool,
+	pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7, done <-chan struct{}) (
+	<-chan []*pb.EarthquakeEvent, error) {
+
+	return defaultCache.watchEarthquakes(magnitude, past, details, pos, bounds,
+		done)
+}
+
+// watchEarthquakes streams batches of events for earthquakes on the cache (see
+// the WatchEarthquakes function)
+func (c *Cache) watchEarthquakes(magnitude pb.Magnitude, past pb.Past,
+	details bool, pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7,
+	done <-chan struct{}) (<-chan []*pb.EarthquakeEvent, error) {
+
+	// ensure data is available on the cache before starting to watch it
+	if _, err := c.getList(context.Background(), magnitude, past); err != nil {
+		return nil, err
+	}
+	w, col, err := c.watch(magnitude, past)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan []*pb.EarthquakeEvent)
+	go func() {
+		defer close(out)
+		defer c.unwatch(magnitude, past, w)
+
+		// send function that gives up when done
+		send := func(events []*pb.EarthquakeEvent) bool {
+			select {
+			case out <- filterEvents(events, details, pos, bounds):
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		// first send current earthquakes as added events
+		if !send(diffCollections(nil, col)) {
+			return
+		}
+
+		// then poll the cache regularly (that refreshes expired data and
+		// notifies watchers with changes) and send events when received
+		ticker := time.NewTicker(c.maxAge(magnitude, past) / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				c.getList(context.Background(), magnitude, past)
+			case events, ok := <-w.events:
+				if !ok || !send(events) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// diffCollections returns events for changes between prev and next
+// collections (all earthquakes on next are added events if prev is nil)
+func diffCollections(prev, next *pb.EarthquakeCollection) []*pb.EarthquakeEvent {
+	known := make(map[string]*pb.Earthquake)
+	if prev != nil {
+		for _, eq := range prev.Features {
+			known[eq.Id] = eq
+		}
+	}
+	var events []*pb.EarthquakeEvent
+	for _, eq := range next.Features {
+		var eventType pb.EventType
+		old, found := known[eq.Id]
+		switch {
+		case isDeleted(eq):
+			if found && !isDeleted(old) {
+				eventType = pb.EventType_EVENT_TYPE_DELETED
+			}
+		case !found || isDeleted(old):
+			eventType = pb.EventType_EVENT_TYPE_ADDED
+		case eq.UpdatedTime != old.UpdatedTime:
+			eventType = pb.EventType_EVENT_TYPE_UPDATED
+		}
+		if eventType != pb.EventType_EVENT_TYPE_UNSPECIFIED {
+			events = append(events, &pb.EarthquakeEvent{
+				Type:    eventType,
+				Feature: eq,
+			})
+		}
+	}
+	return events
+}
+
+// filterEvents filters events by bounds and sorts them by distance to pos
+// (features of events are copied without details if details not asked)
+func filterEvents(events []*pb.EarthquakeEvent, details bool,
+	pos *pb.GeoPointE7, bounds *pb.GeoBoundsE7) []*pb.EarthquakeEvent {
+
+	filtered := make([]*pb.EarthquakeEvent, 0, len(events))
+	for _, ev := range events {
+		eq := ev.Feature
+		if bounds != nil && !earthquakes.InBounds(eq.Position, bounds) {
+			continue // out of bounds, so skip
+		}
+		if !details {
+			eq = cloneEarthquakeWithoutDetails(eq)
+		}
+		filtered = append(filtered, &pb.EarthquakeEvent{
+			Type:    ev.Type,
+			Feature: eq,
+		})
+	}
+	if pos != nil {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			pi := filtered[i].Feature.Position
+			pj := filtered[j].Feature.Position
+			return geolib.DistanceE7(pi.Latitude, pi.Longitude,
+				pos.Latitude, pos.Longitude) <
+				geolib.DistanceE7(pj.Latitude, pj.Longitude,
+					pos.Latitude, pos.Longitude)
+		})
+	}
+	return filtered
+}
+
+func isDeleted(eq *pb.Earthquake) bool {
+	return eq.Details != nil && eq.Details.Status == pb.Status_STATUS_DELETED
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Navibyte (https://navibyte.com). All rights reserved.
+// Use of this source code is governed by a MIT-style license, see the LICENSE.
+
+package usgs
+
+import (
+	"io/ioutil"
+	"testing"
+
+	pb "github.com/navibyte/quake/api/v1"
+)
+
+func TestDiffCollections(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without previous collection all earthquakes are added
+	events := diffCollections(nil, prev)
+	if len(events) != len(prev.Features) {
+		t.Error("invalid event count for initial events")
+	}
+	for _, ev := range events {
+		if ev.Type != pb.EventType_EVENT_TYPE_ADDED {
+			t.Error("initial events should be added events")
+		}
+	}
+
+	// no events for identical collections
+	if events := diffCollections(prev, next); len(events) != 0 {
+		t.Error("no events expected")
+	}
+
+	// update, delete and add one earthquake
+	next.Features[0].UpdatedTime++
+	next.Features[1].Details.Status = pb.Status_STATUS_DELETED
+	next.Features = append(next.Features, &pb.Earthquake{
+		Id:       "test1",
+		Position: &pb.GeoPointE7{},
+	})
+	events = diffCollections(prev, next)
+	if len(events) != 3 {
+		t.Fatal("invalid event count")
+	}
+	if events[0].Type != pb.EventType_EVENT_TYPE_UPDATED ||
+		events[0].Feature.Id != next.Features[0].Id {
+		t.Error("invalid updated event")
+	}
+	if events[1].Type != pb.EventType_EVENT_TYPE_DELETED ||
+		events[1].Feature.Id != next.Features[1].Id {
+		t.Error("invalid deleted event")
+	}
+	if events[2].Type != pb.EventType_EVENT_TYPE_ADDED ||
+		events[2].Feature.Id != "test1" {
+		t.Error("invalid added event")
+	}
+}
+
+func TestFilterEvents(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/4.5_day.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := ToEarthquakeCollection(b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := diffCollections(nil, col)
+
+	// filter by bounds containing "218km NW of Saumlaki, Indonesia"
+	bounds := &pb.GeoBoundsE7{
+		MinLatitude:  -10_0000000,
+		MinLongitude: 125_0000000,
+		MaxLatitude:  0,
+		MaxLongitude: 135_0000000,
+	}
+	filtered := filterEvents(events, false, nil, bounds)
+	if len(filtered) == 0 || len(filtered) == len(events) {
+		t.Fatal("invalid filtered event count")
+	}
+	for _, ev := range filtered {
+		if ev.Feature.Details != nil {
+			t.Error("has details even if asked not")
+		}
+		lat := ev.Feature.Position.Latitude
+		lon := ev.Feature.Position.Longitude
+		if lat < bounds.MinLatitude || lat > bounds.MaxLatitude ||
+			lon < bounds.MinLongitude || lon > bounds.MaxLongitude {
+			t.Error("event out of bounds")
+		}
+	}
+}